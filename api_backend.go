@@ -2,19 +2,18 @@ package paperspace
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
-	"math"
+	"log/slog"
 	"net/http"
-	"net/http/httputil"
 	"os"
 	"time"
 )
 
 var DefaultBaseURL = "https://api.paperspace.io"
+var DefaultUserAgent = "Go Paperspace Gradient 1.0"
 var SuccessStatusCodes = []int{
 	http.StatusOK,
 	http.StatusCreated,
@@ -23,60 +22,128 @@ var SuccessStatusCodes = []int{
 	http.StatusNoContent,
 }
 
+type requestAttemptKey struct{}
+
 type APIBackend struct {
 	BaseURL    string
 	Debug      bool
 	DebugBody  bool
 	HTTPClient *http.Client
-	RetryCount int
+	// Logger receives a record for every attempt, when nil and Debug is set
+	// records are written to stderr
+	Logger *slog.Logger
+	// RetryCount is used with the default retry policy when RetryPolicy is nil
+	RetryCount  int
+	RetryPolicy *RetryPolicy
+	// RateLimiter, when set, delays every attempt to stay within its rate
+	RateLimiter *RateLimiter
+	UserAgent   string
 }
 
 func NewAPIBackend() *APIBackend {
-	apiBackend := APIBackend{
+	apiBackend := newAPIBackend()
+	apiBackend.loadEnv()
+
+	return apiBackend
+}
+
+func newAPIBackend() *APIBackend {
+	return &APIBackend{
 		BaseURL: DefaultBaseURL,
 		HTTPClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
 		RetryCount: 0,
+		UserAgent:  DefaultUserAgent,
 	}
+}
 
+func (c *APIBackend) loadEnv() {
 	baseURL := os.Getenv("PAPERSPACE_BASEURL")
 	if baseURL != "" {
-		apiBackend.BaseURL = baseURL
+		c.BaseURL = baseURL
 	}
 
 	debug := os.Getenv("PAPERSPACE_DEBUG")
 	if debug != "" {
-		apiBackend.Debug = true
+		c.Debug = true
 	}
 
 	debugBody := os.Getenv("PAPERSPACE_DEBUG_BODY")
 	if debugBody != "" {
-		apiBackend.DebugBody = true
+		c.DebugBody = true
 	}
-
-	return &apiBackend
 }
 
 func (c *APIBackend) Request(method string, url string,
 	params, result interface{}, requestParams RequestParams) (res *http.Response, err error) {
-	for i := 0; i < c.RetryCount+1; i++ {
-		retryDuration := time.Duration((math.Pow(2, float64(i))-1)/2*1000) * time.Millisecond
-		time.Sleep(retryDuration)
-
-		res, err = c.request(method, url, params, result, requestParams)
-		if res != nil && res.StatusCode == 429 {
-			continue
-		} else {
-			break
+	ctx := requestParams.Context
+	if ctx == nil {
+		ctx = context.Background()
+		requestParams.Context = ctx
+	}
+
+	retryPolicy := c.retryPolicy()
+	endpoint := rateLimiterEndpoint(method, url, requestParams)
+	start := time.Now()
+
+	for retry := 0; ; retry++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return res, newContextError(ctxErr, err)
 		}
+
+		if c.RateLimiter != nil {
+			if waitErr := c.RateLimiter.Wait(ctx, endpoint); waitErr != nil {
+				return res, newContextError(waitErr, err)
+			}
+		}
+
+		res, err = c.request(method, url, params, result, requestParams, retry+1)
+		if c.RateLimiter != nil {
+			c.RateLimiter.Observe(endpoint, res)
+		}
+		if err == nil {
+			return res, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return res, newContextError(ctxErr, err)
+		}
+		if retry >= retryPolicy.MaxRetries || !retryPolicy.shouldRetry(method, res, err) {
+			return res, err
+		}
+
+		retryDuration := retryPolicy.backoff(retry, res)
+		if retryPolicy.MaxElapsedTime > 0 && time.Since(start)+retryDuration > retryPolicy.MaxElapsedTime {
+			return res, err
+		}
+
+		c.logRetry(ctx, method, url, retry+1, retryDuration)
+		if sleepErr := sleepContext(ctx, retryDuration); sleepErr != nil {
+			return res, newContextError(sleepErr, err)
+		}
+	}
+}
+
+// RequestAttempt returns the attempt number, starting at 1, of the HTTP request
+// made by APIBackend with ctx, or 0 when ctx is not from such a request
+func RequestAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(requestAttemptKey{}).(int)
+	return attempt
+}
+
+func (c *APIBackend) retryPolicy() *RetryPolicy {
+	if c.RetryPolicy != nil {
+		return c.RetryPolicy
 	}
 
-	return res, err
+	retryPolicy := NewRetryPolicy()
+	retryPolicy.MaxRetries = c.RetryCount
+
+	return retryPolicy
 }
 
 func (c *APIBackend) request(method string, url string,
-	params, result interface{}, requestParams RequestParams) (res *http.Response, err error) {
+	params, result interface{}, requestParams RequestParams, attempt int) (res *http.Response, err error) {
 	var data []byte
 	var req *http.Request
 	body := bytes.NewReader(make([]byte, 0))
@@ -97,40 +164,38 @@ func (c *APIBackend) request(method string, url string,
 		return res, err
 	}
 
-	if requestParams.Context != nil {
-		req = req.WithContext(requestParams.Context)
-	}
+	req = req.WithContext(context.WithValue(requestParams.Context, requestAttemptKey{}, attempt))
 
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("User-Agent", "Go Paperspace Gradient 1.0")
+	userAgent := c.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	req.Header.Add("User-Agent", userAgent)
 
 	for key, value := range requestParams.Headers {
 		req.Header.Add(key, value)
 	}
 
-	if c.Debug {
-		requestDump, err := httputil.DumpRequest(req, c.DebugBody)
-		if err != nil {
-			return res, err
-		}
-		c.debug(string(requestDump))
-	}
+	c.logRequest(req, data, attempt)
 
+	start := time.Now()
 	res, err = c.HTTPClient.Do(req)
 	if err != nil {
+		c.logAttempt(req, nil, attempt, time.Since(start), err)
 		return res, err
 	}
 	defer res.Body.Close()
 
-	if c.Debug {
-		responseDump, err := httputil.DumpResponse(res, c.DebugBody)
-		if err != nil {
-			return res, err
-		}
-		c.debug(string(responseDump))
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		c.logAttempt(req, res, attempt, time.Since(start), err)
+		return res, err
 	}
 
+	c.logResponse(req, res, resBody, attempt)
+
 	isSuccessResponse := false
 	for _, statusCode := range SuccessStatusCodes {
 		if res.StatusCode == statusCode {
@@ -140,46 +205,20 @@ func (c *APIBackend) request(method string, url string,
 	}
 
 	if !isSuccessResponse {
-		defer res.Body.Close()
-		errorBody, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return res, err
-		}
-		errorReader := bytes.NewReader(errorBody)
-
-		paperspaceErrorResponse := PaperspaceErrorResponse{}
-		paperspaceError := PaperspaceError{}
-		errorResponseDecoder := json.NewDecoder(errorReader)
-		if err := errorResponseDecoder.Decode(&paperspaceErrorResponse); err != nil {
-			c.debug(string(err.Error()))
-			return res, errors.New("There was a server error, please try your request again")
-		}
+		apiError := newAPIError(req, res, resBody)
+		apiError.PaperspaceError, apiError.Details = decodeErrorBody(res.StatusCode, res.Header.Get("Content-Type"), resBody)
 
-		if paperspaceErrorResponse.Error == nil {
-			errorDecoder := json.NewDecoder(errorReader)
-			if err := errorDecoder.Decode(&paperspaceErrorResponse); err != nil {
-				c.debug(string(err.Error()))
-				return res, errors.New("There was a server error, please try your request again")
-			}
-
-			return res, error(paperspaceError)
-		}
-
-		return res, error(paperspaceErrorResponse.Error)
+		c.logAttempt(req, res, attempt, time.Since(start), apiError)
+		return res, apiError
 	}
 
-	if result != nil {
-		decoder := json.NewDecoder(res.Body)
-		if err = decoder.Decode(result); err != nil {
+	if result != nil && len(bytes.TrimSpace(resBody)) > 0 {
+		if err = json.Unmarshal(resBody, result); err != nil {
+			c.logAttempt(req, res, attempt, time.Since(start), err)
 			return res, err
 		}
 	}
 
+	c.logAttempt(req, res, attempt, time.Since(start), nil)
 	return res, nil
 }
-
-func (c *APIBackend) debug(message string) {
-	if c.Debug {
-		log.Println(message)
-	}
-}
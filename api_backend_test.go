@@ -0,0 +1,111 @@
+package paperspace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestAPIBackend(t *testing.T, handler http.HandlerFunc) (*APIBackend, *int32) {
+	t.Helper()
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	apiBackend := newAPIBackend()
+	apiBackend.BaseURL = server.URL
+
+	return apiBackend, &attempts
+}
+
+func TestAPIBackendCancelDuringBackoff(t *testing.T) {
+	apiBackend, attempts := newTestAPIBackend(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":{"name":"ServiceUnavailable","message":"try again"}}`))
+	})
+	apiBackend.RetryPolicy = &RetryPolicy{
+		MaxRetries:           3,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+		MinBackoff:           time.Minute,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := apiBackend.Request("GET", "/machines/getMachines", nil, nil, RequestParams{Context: ctx})
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("request returned after %s, want it to stop when the context is canceled", elapsed)
+	}
+	if got := atomic.LoadInt32(attempts); got != 1 {
+		t.Errorf("got %d attempts, want 1", got)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want it to match context.Canceled", err)
+	}
+
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("got error %v, want it to unwrap to the last *APIError", err)
+	}
+	if apiError.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, want %d", apiError.StatusCode, http.StatusServiceUnavailable)
+	}
+	if apiError.PaperspaceError == nil || apiError.PaperspaceError.Message != "try again" {
+		t.Errorf("got error %#v, want the message of the last response", apiError.PaperspaceError)
+	}
+}
+
+func TestAPIBackendCanceledContext(t *testing.T) {
+	apiBackend, attempts := newTestAPIBackend(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := apiBackend.Request("GET", "/machines/getMachines", nil, nil, RequestParams{Context: ctx})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want it to match context.Canceled", err)
+	}
+	if got := atomic.LoadInt32(attempts); got != 0 {
+		t.Errorf("got %d attempts, want none", got)
+	}
+}
+
+func TestAPIBackendCancelDuringRateLimiterWait(t *testing.T) {
+	apiBackend, attempts := newTestAPIBackend(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	apiBackend.RateLimiter = NewRateLimiter(0.001, 1)
+
+	if _, err := apiBackend.Request("GET", "/machines/getMachines", nil, nil, RequestParams{}); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := apiBackend.Request("GET", "/machines/getMachines", nil, nil, RequestParams{Context: ctx})
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("request returned after %s, want it to stop when the context is canceled", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want it to match context.Canceled", err)
+	}
+	if got := atomic.LoadInt32(attempts); got != 1 {
+		t.Errorf("got %d attempts, want only the first request to be sent", got)
+	}
+}
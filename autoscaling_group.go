@@ -1,6 +1,7 @@
 package paperspace
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -66,42 +67,78 @@ type AutoscalingGroupUpdateParams struct {
 }
 
 func (c Client) CreateAutoscalingGroup(params AutoscalingGroupCreateParams) (AutoscalingGroup, error) {
+	return c.CreateAutoscalingGroupContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) CreateAutoscalingGroupContext(ctx context.Context, params AutoscalingGroupCreateParams) (AutoscalingGroup, error) {
+	params.Context = ctx
+
 	autoscalingGroup := AutoscalingGroup{}
 
+	if c.PreflightValidation {
+		if err := c.validateMachineType(ctx, "", params.MachineType, params.RequestParams); err != nil {
+			return autoscalingGroup, err
+		}
+	}
+
 	url := fmt.Sprintf("/autoscalingGroups")
-	_, err := c.Request("POST", url, params, &autoscalingGroup, params.RequestParams)
+	_, err := c.Request("POST", url, params, &autoscalingGroup, params.RequestParams.withOperation("CreateAutoscalingGroup"))
 
 	return autoscalingGroup, err
 }
 
 func (c Client) GetAutoscalingGroup(id string, params AutoscalingGroupGetParams) (AutoscalingGroup, error) {
+	return c.GetAutoscalingGroupContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetAutoscalingGroupContext(ctx context.Context, id string, params AutoscalingGroupGetParams) (AutoscalingGroup, error) {
+	params.Context = ctx
+
 	autoscalingGroup := AutoscalingGroup{}
 
 	url := fmt.Sprintf("/autoscalingGroups/%s", id)
-	_, err := c.Request("GET", url, params, &autoscalingGroup, params.RequestParams)
+	_, err := c.Request("GET", url, params, &autoscalingGroup, params.RequestParams.withOperation("GetAutoscalingGroup"))
 
 	return autoscalingGroup, err
 }
 
 func (c Client) GetAutoscalingGroups(params AutoscalingGroupListParams) ([]AutoscalingGroup, error) {
+	return c.GetAutoscalingGroupsContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetAutoscalingGroupsContext(ctx context.Context, params AutoscalingGroupListParams) ([]AutoscalingGroup, error) {
+	params.Context = ctx
+
 	var autoscalingGroups []AutoscalingGroup
 
 	url := fmt.Sprintf("/autoscalingGroups")
-	_, err := c.Request("GET", url, params, &autoscalingGroups, params.RequestParams)
+	_, err := c.Request("GET", url, params, &autoscalingGroups, params.RequestParams.withOperation("GetAutoscalingGroups"))
 
 	return autoscalingGroups, err
 }
 
 func (c Client) UpdateAutoscalingGroup(id string, params AutoscalingGroupUpdateParams) error {
+	return c.UpdateAutoscalingGroupContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) UpdateAutoscalingGroupContext(ctx context.Context, id string, params AutoscalingGroupUpdateParams) error {
+	params.Context = ctx
+
 	url := fmt.Sprintf("/autoscalingGroups/%s", id)
-	_, err := c.Request("PATCH", url, params, nil, params.RequestParams)
+	_, err := c.Request("PATCH", url, params, nil, params.RequestParams.withOperation("UpdateAutoscalingGroup"))
 
 	return err
 }
 
 func (c Client) DeleteAutoscalingGroup(id string, params AutoscalingGroupDeleteParams) error {
+	return c.DeleteAutoscalingGroupContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) DeleteAutoscalingGroupContext(ctx context.Context, id string, params AutoscalingGroupDeleteParams) error {
+	params.Context = ctx
+
 	url := fmt.Sprintf("/autoscalingGroups/%s", id)
-	_, err := c.Request("DELETE", url, nil, nil, params.RequestParams)
+	_, err := c.Request("DELETE", url, nil, nil, params.RequestParams.withOperation("DeleteAutoscalingGroup"))
 
 	return err
 }
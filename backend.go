@@ -7,3 +7,10 @@ import (
 type Backend interface {
 	Request(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error)
 }
+
+// BackendFunc adapts a function to the Backend interface
+type BackendFunc func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error)
+
+func (f BackendFunc) Request(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+	return f(method, url, params, result, requestParams)
+}
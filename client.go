@@ -3,45 +3,84 @@ package paperspace
 import (
 	"context"
 	"net/http"
-	"os"
 )
 
 type RequestParams struct {
 	Context context.Context   `json:"-"`
 	Headers map[string]string `json:"-"`
+	// Operation names the Client method making the request, such as
+	// CreateMachine, for use by middleware in logs, traces and metrics
+	Operation string `json:"-"`
+}
+
+func (p RequestParams) contextOrBackground() context.Context {
+	if p.Context != nil {
+		return p.Context
+	}
+
+	return context.Background()
+}
+
+func (p RequestParams) withOperation(operation string) RequestParams {
+	if p.Operation == "" {
+		p.Operation = operation
+	}
+
+	return p
 }
 
 type Client struct {
 	APIKey  string
 	Backend Backend
+	// CredentialSource records where NewClient found APIKey
+	CredentialSource CredentialSource
+	Middleware       []Middleware
+	// PreflightValidation checks the machine type and region of CreateMachine
+	// and CreateAutoscalingGroup against the machine type catalog first
+	PreflightValidation bool
+
+	regionCache *regionCache
 }
 
-// client that makes requests to Gradient API
-func NewClient() *Client {
-	client := Client{
-		Backend: NewAPIBackend(),
-	}
+// client that makes requests to Gradient API. Without options the client is
+// configured from the environment, with options the environment is only read
+// when WithEnv is given, so clients don't share configuration by accident.
+func NewClient(opts ...Option) *Client {
+	// a missing key leaves the client without one, as an unset
+	// PAPERSPACE_APIKEY always has, NewValidatedClient reports why
+	client, _ := newClientOptions(opts).client()
+	return client
+}
 
-	apiKey := os.Getenv("PAPERSPACE_APIKEY")
-	if apiKey != "" {
-		client.APIKey = apiKey
+// NewValidatedClient is NewClient, returning an error instead of a client
+// without an API key when the credentials can't be resolved, such as when the
+// profile given to WithProfile is missing or the config file is malformed
+func NewValidatedClient(opts ...Option) (*Client, error) {
+	client, err := newClientOptions(opts).client()
+	if err != nil {
+		return nil, err
 	}
 
-	return &client
+	return client, nil
 }
 
 func NewClientWithBackend(backend Backend) *Client {
-	client := NewClient()
-	client.Backend = backend
+	return NewClient(WithEnv(), WithBackend(backend))
+}
 
-	return client
+// Use appends middleware to the chain that wraps every request made by the client
+func (c *Client) Use(middleware ...Middleware) {
+	c.Middleware = append(c.Middleware, middleware...)
 }
 
 func (c *Client) Request(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
-	if requestParams.Headers == nil {
-		requestParams.Headers = make(map[string]string)
+	headers := make(map[string]string, len(requestParams.Headers)+1)
+	for key, value := range requestParams.Headers {
+		headers[key] = value
 	}
-	requestParams.Headers["x-api-key"] = c.APIKey
+	headers["x-api-key"] = c.APIKey
+	requestParams.Headers = headers
 
-	return c.Backend.Request(method, url, params, result, requestParams)
+	backend := chainMiddleware(c.Backend, c.Middleware)
+	return backend.Request(method, url, params, result, requestParams)
 }
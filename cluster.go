@@ -1,6 +1,7 @@
 package paperspace
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -17,6 +18,17 @@ const (
 	ClusterPlatformSambaNova  ClusterPlatformType = "sambanova"
 )
 
+type ClusterStatus string
+
+const (
+	ClusterStatusPending      ClusterStatus = "pending"
+	ClusterStatusProvisioning ClusterStatus = "provisioning"
+	ClusterStatusReady        ClusterStatus = "ready"
+	ClusterStatusFailed       ClusterStatus = "failed"
+	ClusterStatusDeleting     ClusterStatus = "deleting"
+	ClusterStatusDeleted      ClusterStatus = "deleted"
+)
+
 var ClusterAWSRegions = []string{
 	"us-east-1",
 	"us-east-2",
@@ -106,6 +118,10 @@ type Cluster struct {
 	ContainerRegistry *ContainerRegistry  `json:"containerRegistry,omitempty"`
 	TeamID            string              `json:"teamId"`
 	Type              string              `json:"type,omitempty"`
+	// Status is the state of the workflow provisioning the cluster, and
+	// StatusMessage explains why it failed
+	Status        ClusterStatus `json:"status,omitempty"`
+	StatusMessage string        `json:"statusMessage,omitempty"`
 }
 
 type ClusterCreateParams struct {
@@ -126,6 +142,16 @@ type ClusterCreateParams struct {
 	ContainerRegistryPassword   string `json:"containerRegistryPassword,omitempty" yaml:"containerRegistryPassword,omitempty"`
 }
 
+type ClusterDeleteParams struct {
+	RequestParams
+}
+
+type clusterDeleteParamsInternal struct {
+	RequestParams
+
+	ID string `json:"id"`
+}
+
 type ClusterGetParams struct {
 	RequestParams
 }
@@ -176,38 +202,80 @@ func NewClusterListParams() ClusterListParams {
 }
 
 func (c Client) CreateCluster(params ClusterCreateParams) (Cluster, error) {
+	return c.CreateClusterContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) CreateClusterContext(ctx context.Context, params ClusterCreateParams) (Cluster, error) {
+	params.Context = ctx
+
 	cluster := Cluster{}
 	params.Type = DefaultClusterType
 
 	url := "/clusters/createCluster"
-	_, err := c.Request("POST", url, params, &cluster, params.RequestParams)
+	_, err := c.Request("POST", url, params, &cluster, params.RequestParams.withOperation("CreateCluster"))
 
 	return cluster, err
 }
 
 func (c Client) GetCluster(id string, params ClusterGetParams) (Cluster, error) {
+	return c.GetClusterContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetClusterContext(ctx context.Context, id string, params ClusterGetParams) (Cluster, error) {
+	params.Context = ctx
+
 	cluster := Cluster{}
 
 	url := fmt.Sprintf("/clusters/getCluster?id=%s", id)
-	_, err := c.Request("GET", url, nil, &cluster, params.RequestParams)
+	_, err := c.Request("GET", url, nil, &cluster, params.RequestParams.withOperation("GetCluster"))
 
 	return cluster, err
 }
 
 func (c Client) GetClusters(params ClusterListParams) ([]Cluster, error) {
+	return c.GetClustersContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetClustersContext(ctx context.Context, params ClusterListParams) ([]Cluster, error) {
+	params.Context = ctx
+
 	clusters := []Cluster{}
 
 	url := "/clusters/getClusters"
-	_, err := c.Request("GET", url, params, &clusters, params.RequestParams)
+	_, err := c.Request("GET", url, params, &clusters, params.RequestParams.withOperation("GetClusters"))
 
 	return clusters, err
 }
 
 func (c Client) UpdateCluster(id string, params ClusterUpdateParams) (Cluster, error) {
+	return c.UpdateClusterContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) UpdateClusterContext(ctx context.Context, id string, params ClusterUpdateParams) (Cluster, error) {
+	params.Context = ctx
+
 	cluster := Cluster{}
 
 	url := "/clusters/updateCluster"
-	_, err := c.Request("POST", url, params, &cluster, params.RequestParams)
+	_, err := c.Request("POST", url, params, &cluster, params.RequestParams.withOperation("UpdateCluster"))
 
 	return cluster, err
 }
+
+func (c Client) DeleteCluster(id string, params ClusterDeleteParams) error {
+	return c.DeleteClusterContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) DeleteClusterContext(ctx context.Context, id string, params ClusterDeleteParams) error {
+	params.Context = ctx
+
+	intParams := clusterDeleteParamsInternal{
+		ID:            id,
+		RequestParams: params.RequestParams,
+	}
+
+	url := "/clusters/deleteCluster"
+	_, err := c.Request("POST", url, intParams, nil, params.RequestParams.withOperation("DeleteCluster"))
+
+	return err
+}
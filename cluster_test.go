@@ -0,0 +1,33 @@
+package paperspace
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeleteCluster(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/clusters/deleteCluster" {
+			t.Errorf("got request %s %s, want POST /clusters/deleteCluster", r.Method, r.URL.Path)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("invalid request body %s: %v", data, err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL))
+	if err := client.DeleteCluster("cl123", ClusterDeleteParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(body) != 1 || body["id"] != "cl123" {
+		t.Errorf("got request body %v, want only the cluster id", body)
+	}
+}
@@ -0,0 +1,218 @@
+package paperspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const DefaultProfile = "default"
+
+var ErrNoCredentials = errors.New("no Paperspace API key found")
+
+// Credentials are the API key and base URL used by a Client, along with where
+// they were found
+type Credentials struct {
+	APIKey  string
+	BaseURL string
+	Source  CredentialSource
+}
+
+type CredentialSource struct {
+	// Provider is one of "option", "env" or "config"
+	Provider string
+	Path     string
+	Profile  string
+}
+
+func (s CredentialSource) String() string {
+	switch {
+	case s.Provider == "":
+		return "none"
+	case s.Path != "":
+		return fmt.Sprintf("%s %s (profile %s)", s.Provider, s.Path, s.Profile)
+	default:
+		return s.Provider
+	}
+}
+
+type CredentialProvider interface {
+	// Retrieve returns empty Credentials without an error when the provider
+	// has nothing configured
+	Retrieve() (Credentials, error)
+}
+
+type StaticCredentialProvider struct {
+	APIKey  string
+	BaseURL string
+}
+
+func (p StaticCredentialProvider) Retrieve() (Credentials, error) {
+	return Credentials{
+		APIKey:  p.APIKey,
+		BaseURL: p.BaseURL,
+		Source:  CredentialSource{Provider: "option"},
+	}, nil
+}
+
+// EnvCredentialProvider reads PAPERSPACE_APIKEY and PAPERSPACE_BASEURL
+type EnvCredentialProvider struct{}
+
+func (p EnvCredentialProvider) Retrieve() (Credentials, error) {
+	return Credentials{
+		APIKey:  os.Getenv("PAPERSPACE_APIKEY"),
+		BaseURL: os.Getenv("PAPERSPACE_BASEURL"),
+		Source:  CredentialSource{Provider: "env"},
+	}, nil
+}
+
+// ConfigFile is the format of ~/.paperspace/config.json. The top level apiKey
+// written by the paperspace CLI is used as the default profile.
+type ConfigFile struct {
+	APIKey   string                   `json:"apiKey,omitempty"`
+	BaseURL  string                   `json:"baseUrl,omitempty"`
+	Profiles map[string]ConfigProfile `json:"profiles,omitempty"`
+}
+
+type ConfigProfile struct {
+	APIKey  string `json:"apiKey"`
+	BaseURL string `json:"baseUrl,omitempty"`
+}
+
+func (f ConfigFile) Profile(name string) (ConfigProfile, bool) {
+	if profile, ok := f.Profiles[name]; ok {
+		return profile, true
+	}
+
+	if name == DefaultProfile && f.APIKey != "" {
+		return ConfigProfile{APIKey: f.APIKey, BaseURL: f.BaseURL}, true
+	}
+
+	return ConfigProfile{}, false
+}
+
+// DefaultConfigFilePath returns PAPERSPACE_CONFIG_FILE when set, or ~/.paperspace/config.json
+func DefaultConfigFilePath() string {
+	if path := os.Getenv("PAPERSPACE_CONFIG_FILE"); path != "" {
+		return path
+	}
+
+	return homeConfigFilePath()
+}
+
+func homeConfigFilePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".paperspace", "config.json")
+}
+
+// ConfigFileCredentialProvider reads a profile from a config file. Path
+// defaults to DefaultConfigFilePath and Profile to PAPERSPACE_PROFILE or
+// "default". A missing file is not an error, a missing profile is unless it is
+// the default profile.
+type ConfigFileCredentialProvider struct {
+	Path    string
+	Profile string
+	// IgnoreEnv skips PAPERSPACE_CONFIG_FILE and PAPERSPACE_PROFILE, so Path
+	// defaults to ~/.paperspace/config.json and Profile to "default"
+	IgnoreEnv bool
+}
+
+func (p ConfigFileCredentialProvider) Retrieve() (Credentials, error) {
+	path := p.Path
+	if path == "" && p.IgnoreEnv {
+		path = homeConfigFilePath()
+	} else if path == "" {
+		path = DefaultConfigFilePath()
+	}
+
+	profileName := p.Profile
+	if profileName == "" && !p.IgnoreEnv {
+		profileName = os.Getenv("PAPERSPACE_PROFILE")
+	}
+	if profileName == "" {
+		profileName = DefaultProfile
+	}
+
+	source := CredentialSource{Provider: "config", Path: path, Profile: profileName}
+	if path == "" {
+		return Credentials{Source: source}, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Credentials{Source: source}, nil
+	}
+	if err != nil {
+		return Credentials{Source: source}, err
+	}
+
+	configFile := ConfigFile{}
+	if err := json.Unmarshal(data, &configFile); err != nil {
+		return Credentials{Source: source}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	profile, ok := configFile.Profile(profileName)
+	if !ok {
+		if profileName == DefaultProfile {
+			return Credentials{Source: source}, nil
+		}
+		return Credentials{Source: source}, fmt.Errorf("no profile %s found in %s", profileName, path)
+	}
+
+	return Credentials{
+		APIKey:  profile.APIKey,
+		BaseURL: profile.BaseURL,
+		Source:  source,
+	}, nil
+}
+
+// ChainCredentialProvider takes the API key from the first provider that has
+// one, and the base URL from the first provider up to and including it.
+// Errors are returned only when no provider has an API key.
+type ChainCredentialProvider []CredentialProvider
+
+func (p ChainCredentialProvider) Retrieve() (Credentials, error) {
+	credentials := Credentials{}
+	var errs []error
+
+	for _, provider := range p {
+		providerCredentials, err := provider.Retrieve()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if credentials.BaseURL == "" {
+			credentials.BaseURL = providerCredentials.BaseURL
+		}
+		if providerCredentials.APIKey != "" {
+			credentials.APIKey = providerCredentials.APIKey
+			credentials.Source = providerCredentials.Source
+			break
+		}
+	}
+
+	if credentials.APIKey == "" {
+		if len(errs) > 0 {
+			return credentials, errors.Join(errs...)
+		}
+		return credentials, ErrNoCredentials
+	}
+
+	return credentials, nil
+}
+
+// DefaultCredentialProvider looks in the environment and then in the config file
+func DefaultCredentialProvider() CredentialProvider {
+	return ChainCredentialProvider{
+		EnvCredentialProvider{},
+		ConfigFileCredentialProvider{},
+	}
+}
@@ -1,5 +1,11 @@
 package paperspace
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
 type PaperspaceErrorResponse struct {
 	Error *PaperspaceError `json:"error"`
 }
@@ -13,3 +19,151 @@ type PaperspaceError struct {
 func (e PaperspaceError) Error() string {
 	return e.Message
 }
+
+// ContextError is returned when the request context ends before APIBackend
+// finishes retrying. It matches the context error with errors.Is and unwraps
+// to the error of the last attempt.
+type ContextError struct {
+	Err     error
+	LastErr error
+}
+
+func newContextError(ctxErr error, lastErr error) error {
+	if lastErr == nil {
+		return ctxErr
+	}
+
+	return ContextError{Err: ctxErr, LastErr: lastErr}
+}
+
+func (e ContextError) Error() string {
+	return fmt.Sprintf("%s: last error: %s", e.Err, e.LastErr)
+}
+
+func (e ContextError) Is(target error) bool {
+	return target == e.Err
+}
+
+func (e ContextError) Unwrap() error {
+	return e.LastErr
+}
+
+// NotFoundError is returned when a lookup made by the client, such as
+// resolving a template label or a region name, finds nothing. IsNotFound
+// reports it the same way as a 404 from the API.
+type NotFoundError struct {
+	// Resource is the kind of resource looked up, such as network or region
+	Resource string
+	// Key describes what was looked up, such as "ID ns123" or "label Ubuntu"
+	Key string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("no %s found for %s", e.Resource, e.Key)
+}
+
+var RequestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Amzn-RequestId",
+}
+
+// APIError is returned for every non-successful response from the Paperspace API
+type APIError struct {
+	StatusCode      int
+	Method          string
+	URL             string
+	Header          http.Header
+	RequestID       string
+	Body            []byte
+	PaperspaceError *PaperspaceError
+	// Details are the validation errors listed in the response, if any
+	Details PaperspaceErrorDetails
+}
+
+func newAPIError(req *http.Request, res *http.Response, body []byte) *APIError {
+	apiError := APIError{
+		StatusCode: res.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Header:     res.Header,
+		Body:       body,
+		RequestID:  responseRequestID(res),
+	}
+
+	return &apiError
+}
+
+func (e *APIError) Error() string {
+	message := http.StatusText(e.StatusCode)
+	if e.PaperspaceError != nil && e.PaperspaceError.Message != "" {
+		message = e.PaperspaceError.Message
+	}
+
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s %s: %d %s (request id %s)", e.Method, e.URL, e.StatusCode, message, e.RequestID)
+	}
+
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, message)
+}
+
+func (e *APIError) Unwrap() error {
+	if e.PaperspaceError == nil {
+		return nil
+	}
+
+	return e.PaperspaceError
+}
+
+// StatusCode returns the HTTP status of the API error wrapped by err, or 0 if there is none
+func StatusCode(err error) int {
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode
+	}
+
+	return 0
+}
+
+func IsBadRequest(err error) bool {
+	return StatusCode(err) == http.StatusBadRequest
+}
+
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsNotFound reports a 404 from the API or a NotFoundError
+func IsNotFound(err error) bool {
+	var notFoundError NotFoundError
+	return StatusCode(err) == http.StatusNotFound || errors.As(err, &notFoundError)
+}
+
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+func IsValidation(err error) bool {
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+
+	if len(apiError.Details) > 0 ||
+		(apiError.PaperspaceError != nil && apiError.PaperspaceError.Name == "ValidationError") {
+		return true
+	}
+
+	return apiError.StatusCode == http.StatusUnprocessableEntity
+}
+
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+func IsServerError(err error) bool {
+	return StatusCode(err) >= http.StatusInternalServerError
+}
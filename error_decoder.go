@@ -0,0 +1,295 @@
+package paperspace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxErrorMessageLength = 512
+
+var htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+var htmlTagPattern = regexp.MustCompile(`(?s)<[^>]*>`)
+
+type PaperspaceErrorDetail struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// PaperspaceErrorDetails decodes the validation details returned by the API,
+// which are either a list of messages or a map of field names to messages.
+// Details of any other shape are kept as a single detail holding their JSON,
+// so they never keep the rest of an error from being decoded.
+type PaperspaceErrorDetails []PaperspaceErrorDetail
+
+func (d *PaperspaceErrorDetails) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	switch data[0] {
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			break
+		}
+
+		for _, item := range items {
+			*d = append(*d, decodeErrorDetail(item))
+		}
+		return nil
+	case '{':
+		var validation struct {
+			Codes    map[string][]string `json:"codes"`
+			Messages map[string][]string `json:"messages"`
+		}
+		if err := json.Unmarshal(data, &validation); err != nil || len(validation.Messages) == 0 {
+			break
+		}
+
+		fields := make([]string, 0, len(validation.Messages))
+		for field := range validation.Messages {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		for _, field := range fields {
+			codes := validation.Codes[field]
+			for i, message := range validation.Messages[field] {
+				detail := PaperspaceErrorDetail{Field: field, Message: message}
+				if i < len(codes) {
+					detail.Code = codes[i]
+				}
+				*d = append(*d, detail)
+			}
+		}
+		return nil
+	}
+
+	*d = append(*d, decodeErrorDetail(data))
+	return nil
+}
+
+func decodeErrorDetail(data json.RawMessage) PaperspaceErrorDetail {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		return PaperspaceErrorDetail{Message: message}
+	}
+
+	var detail struct {
+		Field   string          `json:"field"`
+		Path    json.RawMessage `json:"path"`
+		Param   string          `json:"param"`
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+		Msg     string          `json:"msg"`
+	}
+	err := json.Unmarshal(data, &detail)
+	if err != nil || (detail.Field == "" && len(detail.Path) == 0 && detail.Param == "" &&
+		detail.Code == "" && detail.Message == "" && detail.Msg == "") {
+		return PaperspaceErrorDetail{Message: truncateErrorMessage(compactJSON(data))}
+	}
+
+	paperspaceErrorDetail := PaperspaceErrorDetail{
+		Field:   detail.Field,
+		Code:    detail.Code,
+		Message: detail.Message,
+	}
+	if paperspaceErrorDetail.Field == "" {
+		paperspaceErrorDetail.Field = detail.Param
+	}
+	if paperspaceErrorDetail.Field == "" && len(detail.Path) > 0 {
+		paperspaceErrorDetail.Field = decodeErrorPath(detail.Path)
+	}
+	if paperspaceErrorDetail.Message == "" {
+		paperspaceErrorDetail.Message = detail.Msg
+	}
+
+	return paperspaceErrorDetail
+}
+
+func compactJSON(data []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return string(data)
+	}
+
+	return buf.String()
+}
+
+func decodeErrorPath(data json.RawMessage) string {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		return path
+	}
+
+	var segments []interface{}
+	if err := json.Unmarshal(data, &segments); err != nil {
+		return ""
+	}
+
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		parts[i] = fmt.Sprint(segment)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+type rawPaperspaceError struct {
+	Name       string                 `json:"name"`
+	Message    string                 `json:"message"`
+	Status     json.RawMessage        `json:"status"`
+	StatusCode json.RawMessage        `json:"statusCode"`
+	Details    PaperspaceErrorDetails `json:"details"`
+	Errors     PaperspaceErrorDetails `json:"errors"`
+}
+
+func (e rawPaperspaceError) isEmpty() bool {
+	return e.Name == "" && e.Message == "" && len(e.Details) == 0 && len(e.Errors) == 0
+}
+
+func (e rawPaperspaceError) paperspaceError() (*PaperspaceError, PaperspaceErrorDetails) {
+	paperspaceError := PaperspaceError{
+		Name:    e.Name,
+		Message: e.Message,
+		Status:  decodeErrorStatus(e.Status),
+	}
+	if paperspaceError.Status == 0 {
+		paperspaceError.Status = decodeErrorStatus(e.StatusCode)
+	}
+
+	return &paperspaceError, append(e.Details, e.Errors...)
+}
+
+type rawPaperspaceErrorResponse struct {
+	rawPaperspaceError
+
+	Error json.RawMessage `json:"error"`
+}
+
+func decodeErrorStatus(data json.RawMessage) int {
+	if len(data) == 0 {
+		return 0
+	}
+
+	var status int
+	if err := json.Unmarshal(data, &status); err == nil {
+		return status
+	}
+
+	var statusString string
+	if err := json.Unmarshal(data, &statusString); err == nil {
+		status, _ = strconv.Atoi(statusString)
+	}
+
+	return status
+}
+
+// decodeErrorBody turns any error response body into a PaperspaceError and
+// its validation details. It understands errors wrapped in an "error" key,
+// flat errors, validation details, and falls back to the text of plain text
+// and HTML bodies.
+func decodeErrorBody(statusCode int, contentType string, body []byte) (*PaperspaceError, PaperspaceErrorDetails) {
+	body = bytes.TrimSpace(body)
+
+	paperspaceError, details := decodeJSONErrorBody(body)
+	if paperspaceError == nil {
+		paperspaceError = &PaperspaceError{Message: decodeTextErrorBody(contentType, body)}
+	}
+
+	if paperspaceError.Status == 0 {
+		paperspaceError.Status = statusCode
+	}
+	if paperspaceError.Name == "" {
+		paperspaceError.Name = http.StatusText(statusCode)
+	}
+	if paperspaceError.Message == "" {
+		paperspaceError.Message = truncateErrorMessage(detailsMessage(details))
+	}
+	if paperspaceError.Message == "" {
+		paperspaceError.Message = http.StatusText(statusCode)
+	}
+
+	return paperspaceError, details
+}
+
+func decodeJSONErrorBody(body []byte) (*PaperspaceError, PaperspaceErrorDetails) {
+	if len(body) == 0 || body[0] != '{' {
+		return nil, nil
+	}
+
+	var response rawPaperspaceErrorResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, nil
+	}
+
+	errorData := bytes.TrimSpace(response.Error)
+	if len(errorData) > 0 && !bytes.Equal(errorData, []byte("null")) {
+		var message string
+		if err := json.Unmarshal(errorData, &message); err == nil {
+			paperspaceError, details := response.paperspaceError()
+			paperspaceError.Message = message
+			return paperspaceError, details
+		}
+
+		var wrappedError rawPaperspaceError
+		if err := json.Unmarshal(errorData, &wrappedError); err == nil && !wrappedError.isEmpty() {
+			return wrappedError.paperspaceError()
+		}
+	}
+
+	if response.isEmpty() {
+		return &PaperspaceError{Message: truncateErrorMessage(string(body))}, nil
+	}
+
+	return response.paperspaceError()
+}
+
+func decodeTextErrorBody(contentType string, body []byte) string {
+	text := string(body)
+
+	if strings.Contains(contentType, "html") || strings.HasPrefix(text, "<") {
+		if match := htmlTitlePattern.FindStringSubmatch(text); match != nil && strings.TrimSpace(match[1]) != "" {
+			text = match[1]
+		} else {
+			text = htmlTagPattern.ReplaceAllString(text, " ")
+		}
+	}
+
+	return truncateErrorMessage(strings.Join(strings.Fields(text), " "))
+}
+
+func detailsMessage(details PaperspaceErrorDetails) string {
+	messages := make([]string, 0, len(details))
+	for _, detail := range details {
+		if detail.Field != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", detail.Field, detail.Message))
+		} else {
+			messages = append(messages, detail.Message)
+		}
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func truncateErrorMessage(message string) string {
+	if len(message) <= maxErrorMessageLength {
+		return message
+	}
+
+	message = message[:maxErrorMessageLength]
+	for !utf8.ValidString(message) {
+		message = message[:len(message)-1]
+	}
+
+	return message + "..."
+}
@@ -0,0 +1,173 @@
+package paperspace
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeErrorBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		contentType string
+		body        string
+		want        PaperspaceError
+		details     PaperspaceErrorDetails
+	}{
+		{
+			name:       "wrapped",
+			statusCode: http.StatusNotFound,
+			body:       `{"error":{"name":"NotFound","message":"Machine not found","status":404}}`,
+			want:       PaperspaceError{Name: "NotFound", Message: "Machine not found", Status: 404},
+		},
+		{
+			name:       "flat",
+			statusCode: http.StatusBadRequest,
+			body:       `{"name":"BadRequest","message":"invalid machine type","statusCode":400}`,
+			want:       PaperspaceError{Name: "BadRequest", Message: "invalid machine type", Status: 400},
+		},
+		{
+			name:       "string status",
+			statusCode: http.StatusConflict,
+			body:       `{"error":{"message":"name taken","status":"409"}}`,
+			want:       PaperspaceError{Name: "Conflict", Message: "name taken", Status: 409},
+		},
+		{
+			name:       "string error",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error":"invalid api key"}`,
+			want:       PaperspaceError{Name: "Unauthorized", Message: "invalid api key", Status: 401},
+		},
+		{
+			name:       "validation map",
+			statusCode: http.StatusUnprocessableEntity,
+			body: `{"error":{"name":"ValidationError","status":422,"details":{` +
+				`"codes":{"name":["presence"],"region":["inclusion"]},` +
+				`"messages":{"name":["can't be blank"],"region":["is not included in the list"]}}}}`,
+			want: PaperspaceError{
+				Name:    "ValidationError",
+				Message: "name: can't be blank; region: is not included in the list",
+				Status:  422,
+			},
+			details: PaperspaceErrorDetails{
+				{Field: "name", Code: "presence", Message: "can't be blank"},
+				{Field: "region", Code: "inclusion", Message: "is not included in the list"},
+			},
+		},
+		{
+			name:       "validation array",
+			statusCode: http.StatusBadRequest,
+			body:       `{"message":"Invalid request","errors":[{"param":"machineType","msg":"is required"},{"path":["ports",0],"message":"is invalid"},"too many machines"]}`,
+			want:       PaperspaceError{Name: "Bad Request", Message: "Invalid request", Status: 400},
+			details: PaperspaceErrorDetails{
+				{Field: "machineType", Message: "is required"},
+				{Field: "ports.0", Message: "is invalid"},
+				{Message: "too many machines"},
+			},
+		},
+		{
+			name:       "details of unexpected shape",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":{"name":"BadRequest","message":"Invalid template","status":400,"details":42}}`,
+			want:       PaperspaceError{Name: "BadRequest", Message: "Invalid template", Status: 400},
+			details:    PaperspaceErrorDetails{{Message: "42"}},
+		},
+		{
+			name:       "details object of unexpected shape",
+			statusCode: http.StatusForbidden,
+			body:       `{"error":{"name":"QuotaExceeded","message":"Quota exceeded","details":{"limit": 2, "used": 2}}}`,
+			want:       PaperspaceError{Name: "QuotaExceeded", Message: "Quota exceeded", Status: 403},
+			details:    PaperspaceErrorDetails{{Message: `{"limit":2,"used":2}`}},
+		},
+		{
+			name:       "details array of unexpected shape",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":{"message":"Invalid ports","details":[1,{"field":"ports"}]}}`,
+			want:       PaperspaceError{Name: "Bad Request", Message: "Invalid ports", Status: 400},
+			details:    PaperspaceErrorDetails{{Message: "1"}, {Field: "ports"}},
+		},
+		{
+			name:       "unknown JSON",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"code":"E_INTERNAL"}`,
+			want:       PaperspaceError{Name: "Internal Server Error", Message: `{"code":"E_INTERNAL"}`, Status: 500},
+		},
+		{
+			name:        "plain text",
+			statusCode:  http.StatusBadGateway,
+			contentType: "text/plain",
+			body:        "upstream connect error\n",
+			want:        PaperspaceError{Name: "Bad Gateway", Message: "upstream connect error", Status: 502},
+		},
+		{
+			name:        "HTML with title",
+			statusCode:  http.StatusServiceUnavailable,
+			contentType: "text/html",
+			body:        "<html><head><title>503 Service Temporarily Unavailable</title></head><body><h1>503</h1></body></html>",
+			want:        PaperspaceError{Name: "Service Unavailable", Message: "503 Service Temporarily Unavailable", Status: 503},
+		},
+		{
+			name:       "HTML without title",
+			statusCode: http.StatusBadGateway,
+			body:       "<html><body><h1>Bad</h1>\n<p>gateway</p></body></html>",
+			want:       PaperspaceError{Name: "Bad Gateway", Message: "Bad gateway", Status: 502},
+		},
+		{
+			name:       "empty",
+			statusCode: http.StatusGatewayTimeout,
+			want:       PaperspaceError{Name: "Gateway Timeout", Message: "Gateway Timeout", Status: 504},
+		},
+		{
+			name:        "truncated",
+			statusCode:  http.StatusInternalServerError,
+			contentType: "text/plain",
+			body:        strings.Repeat("é", 300),
+			want:        PaperspaceError{Name: "Internal Server Error", Message: strings.Repeat("é", 256) + "...", Status: 500},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paperspaceError, details := decodeErrorBody(test.statusCode, test.contentType, []byte(test.body))
+
+			if paperspaceError == nil || *paperspaceError != test.want {
+				t.Errorf("got error %#v, want %#v", paperspaceError, test.want)
+			}
+			if !reflect.DeepEqual(details, test.details) {
+				t.Errorf("got details %#v, want %#v", details, test.details)
+			}
+		})
+	}
+}
+
+func TestAPIErrorKeepsBody(t *testing.T) {
+	body := []byte(`{"error":{"name":"ValidationError","message":"Invalid","details":[{"param":"name","msg":"is required"}]}}`)
+	apiBackend, _ := newTestAPIBackend(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(body)
+	})
+
+	_, err := apiBackend.Request("POST", "/machines/createSingleMachinePublic", nil, nil, RequestParams{})
+
+	var apiError *APIError
+	if !errors.As(err, &apiError) {
+		t.Fatalf("got error %v, want an *APIError", err)
+	}
+	if !bytes.Equal(apiError.Body, body) {
+		t.Errorf("got body %s, want %s", apiError.Body, body)
+	}
+	if apiError.RequestID != "req-1" {
+		t.Errorf("got request id %q, want %q", apiError.RequestID, "req-1")
+	}
+	if len(apiError.Details) != 1 || apiError.Details[0].Field != "name" {
+		t.Errorf("got details %#v, want the name detail", apiError.Details)
+	}
+	if !IsValidation(err) {
+		t.Errorf("got IsValidation false for %v", err)
+	}
+}
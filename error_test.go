@@ -0,0 +1,125 @@
+package paperspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorError(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiError APIError
+		want     string
+	}{
+		{
+			name:     "message",
+			apiError: APIError{StatusCode: 404, Method: "GET", URL: "/machines/getMachinePublic", PaperspaceError: &PaperspaceError{Message: "Machine not found"}},
+			want:     "GET /machines/getMachinePublic: 404 Machine not found",
+		},
+		{
+			name:     "request id",
+			apiError: APIError{StatusCode: 500, Method: "POST", URL: "/networks", RequestID: "req-1", PaperspaceError: &PaperspaceError{Message: "oops"}},
+			want:     "POST /networks: 500 oops (request id req-1)",
+		},
+		{
+			name:     "no message",
+			apiError: APIError{StatusCode: 502, Method: "GET", URL: "/regions"},
+			want:     "GET /regions: 502 Bad Gateway",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.apiError.Error(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorUnwrap(t *testing.T) {
+	paperspaceError := &PaperspaceError{Name: "NotFound", Message: "Machine not found", Status: 404}
+	err := fmt.Errorf("getting machine: %w", &APIError{StatusCode: 404, PaperspaceError: paperspaceError})
+
+	var unwrapped *PaperspaceError
+	if !errors.As(err, &unwrapped) || unwrapped != paperspaceError {
+		t.Errorf("got %v, want the wrapped PaperspaceError", unwrapped)
+	}
+
+	if (&APIError{StatusCode: 502}).Unwrap() != nil {
+		t.Error("got an unwrapped error from an APIError without a PaperspaceError")
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	helpers := map[string]func(error) bool{
+		"IsBadRequest":   IsBadRequest,
+		"IsUnauthorized": IsUnauthorized,
+		"IsForbidden":    IsForbidden,
+		"IsNotFound":     IsNotFound,
+		"IsConflict":     IsConflict,
+		"IsValidation":   IsValidation,
+		"IsRateLimited":  IsRateLimited,
+		"IsServerError":  IsServerError,
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"400", &APIError{StatusCode: 400}, []string{"IsBadRequest"}},
+		{"401", &APIError{StatusCode: 401}, []string{"IsUnauthorized"}},
+		{"403", &APIError{StatusCode: 403}, []string{"IsForbidden"}},
+		{"404", &APIError{StatusCode: 404}, []string{"IsNotFound"}},
+		{"409", &APIError{StatusCode: 409}, []string{"IsConflict"}},
+		{"422", &APIError{StatusCode: 422}, []string{"IsValidation"}},
+		{"429", &APIError{StatusCode: 429}, []string{"IsRateLimited"}},
+		{"503", &APIError{StatusCode: 503}, []string{"IsServerError"}},
+		{"validation details", &APIError{StatusCode: 400, Details: PaperspaceErrorDetails{{Message: "is required"}}}, []string{"IsBadRequest", "IsValidation"}},
+		{"validation name", &APIError{StatusCode: 400, PaperspaceError: &PaperspaceError{Name: "ValidationError"}}, []string{"IsBadRequest", "IsValidation"}},
+		{"wrapped", fmt.Errorf("creating machine: %w", &APIError{StatusCode: 409}), []string{"IsConflict"}},
+		{"context error", ContextError{Err: context.Canceled, LastErr: &APIError{StatusCode: 429}}, []string{"IsRateLimited"}},
+		{"not found error", fmt.Errorf("resolving: %w", NotFoundError{Resource: "region", Key: "NY9"}), []string{"IsNotFound"}},
+		{"other error", errors.New("connection reset"), nil},
+		{"nil", nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			want := map[string]bool{}
+			for _, name := range test.want {
+				want[name] = true
+			}
+
+			for name, helper := range helpers {
+				if got := helper(test.err); got != want[name] {
+					t.Errorf("got %s %v, want %v", name, got, want[name])
+				}
+			}
+		})
+	}
+}
+
+func TestClientLookupsNotFound(t *testing.T) {
+	client, _ := newTestClient(t, testResponse{http.StatusOK, `[]`})
+	ctx := context.Background()
+
+	_, err := client.GetNetworkContext(ctx, "ns123", NetworkGetParams{})
+	if !IsNotFound(err) {
+		t.Errorf("got GetNetwork error %v, want not found", err)
+	}
+
+	_, err = client.ResolveTemplateIDContext(ctx, "Ubuntu", TemplateListParams{})
+	if !IsNotFound(err) {
+		t.Errorf("got ResolveTemplateID error %v, want not found", err)
+	}
+
+	_, err = client.ResolveRegionContext(ctx, "NY9", RegionListParams{})
+	if !IsNotFound(err) {
+		t.Errorf("got ResolveRegion error %v, want not found", err)
+	}
+}
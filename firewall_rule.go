@@ -0,0 +1,236 @@
+package paperspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+type FirewallProtocol string
+
+const (
+	FirewallProtocolAll  FirewallProtocol = "all"
+	FirewallProtocolICMP FirewallProtocol = "icmp"
+	FirewallProtocolTCP  FirewallProtocol = "tcp"
+	FirewallProtocolUDP  FirewallProtocol = "udp"
+)
+
+var FirewallProtocols = []FirewallProtocol{
+	FirewallProtocolAll,
+	FirewallProtocolICMP,
+	FirewallProtocolTCP,
+	FirewallProtocolUDP,
+}
+
+// PortRange is an inclusive range of ports, From and To are equal for a single port
+type PortRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+func Port(port int) PortRange {
+	return PortRange{From: port, To: port}
+}
+
+// FirewallRule allows ingress traffic from the source CIDRs to the ports of a
+// machine or of every machine on a network
+type FirewallRule struct {
+	ID          string           `json:"id"`
+	Description string           `json:"description"`
+	Protocol    FirewallProtocol `json:"protocol"`
+	PortRanges  []PortRange      `json:"portRanges"`
+	SourceCIDRs []string         `json:"sourceCidrs"`
+	MachineID   string           `json:"machineId"`
+	NetworkID   string           `json:"networkId"`
+	TeamID      string           `json:"teamId"`
+	DtCreated   time.Time        `json:"dtCreated"`
+}
+
+type FirewallRuleCreateParams struct {
+	RequestParams
+
+	Description string           `json:"description,omitempty"`
+	Protocol    FirewallProtocol `json:"protocol"`
+	PortRanges  []PortRange      `json:"portRanges,omitempty"`
+	SourceCIDRs []string         `json:"sourceCidrs"`
+	MachineID   string           `json:"machineId,omitempty"`
+	NetworkID   string           `json:"networkId,omitempty"`
+}
+
+type FirewallRuleDeleteParams struct {
+	RequestParams
+}
+
+type FirewallRuleGetParams struct {
+	RequestParams
+}
+
+type FirewallRuleListParams struct {
+	RequestParams
+
+	Filter    Filter `json:"filter,omitempty"`
+	MachineID string `json:"machineId,omitempty"`
+	NetworkID string `json:"networkId,omitempty"`
+}
+
+// FirewallRuleUpdateParams leaves empty and nil fields unchanged. Set
+// PortRanges to PortRanges() to clear the ports, which happens by default when
+// the protocol changes to icmp or all. SourceCIDRs can be replaced but not
+// cleared, as a rule needs at least one.
+type FirewallRuleUpdateParams struct {
+	RequestParams
+
+	Description string           `json:"description,omitempty"`
+	Protocol    FirewallProtocol `json:"protocol,omitempty"`
+	PortRanges  *[]PortRange     `json:"portRanges,omitempty"`
+	SourceCIDRs *[]string        `json:"sourceCidrs,omitempty"`
+}
+
+func (p FirewallRuleCreateParams) validate() error {
+	if (p.MachineID == "") == (p.NetworkID == "") {
+		return errors.New("firewall rule must be attached to either a machine or a network")
+	}
+	if p.Protocol == "" {
+		return errors.New("firewall rule protocol is required")
+	}
+
+	return validateFirewallRule(p.Protocol, &p.PortRanges, &p.SourceCIDRs)
+}
+
+// validateFirewallRule checks the protocol when it's given, and the port
+// ranges and source CIDRs when they aren't nil. Ports are required for tcp and
+// udp and not allowed for icmp and all.
+func validateFirewallRule(protocol FirewallProtocol, portRanges *[]PortRange, sourceCIDRs *[]string) error {
+	if protocol != "" {
+		valid := false
+		for _, firewallProtocol := range FirewallProtocols {
+			if protocol == firewallProtocol {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("invalid firewall rule protocol %s", protocol)
+		}
+	}
+
+	if portRanges != nil {
+		switch protocol {
+		case FirewallProtocolTCP, FirewallProtocolUDP:
+			if len(*portRanges) == 0 {
+				return fmt.Errorf("firewall rule protocol %s requires at least one port range", protocol)
+			}
+		case FirewallProtocolICMP, FirewallProtocolAll:
+			if len(*portRanges) > 0 {
+				return fmt.Errorf("firewall rule protocol %s does not take port ranges", protocol)
+			}
+		}
+
+		for _, portRange := range *portRanges {
+			if portRange.From < 1 || portRange.To > 65535 || portRange.From > portRange.To {
+				return fmt.Errorf("invalid firewall rule port range %d-%d", portRange.From, portRange.To)
+			}
+		}
+	}
+
+	if sourceCIDRs != nil {
+		if len(*sourceCIDRs) == 0 {
+			return errors.New("firewall rule requires at least one source CIDR")
+		}
+
+		for _, sourceCIDR := range *sourceCIDRs {
+			if _, err := netip.ParsePrefix(sourceCIDR); err != nil {
+				return fmt.Errorf("invalid firewall rule source CIDR %s: %w", sourceCIDR, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c Client) CreateFirewallRule(params FirewallRuleCreateParams) (FirewallRule, error) {
+	return c.CreateFirewallRuleContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) CreateFirewallRuleContext(ctx context.Context, params FirewallRuleCreateParams) (FirewallRule, error) {
+	params.Context = ctx
+
+	firewallRule := FirewallRule{}
+	if err := params.validate(); err != nil {
+		return firewallRule, err
+	}
+
+	url := "/firewallRules"
+	_, err := c.Request("POST", url, params, &firewallRule, params.RequestParams.withOperation("CreateFirewallRule"))
+
+	return firewallRule, err
+}
+
+func (c Client) GetFirewallRule(id string, params FirewallRuleGetParams) (FirewallRule, error) {
+	return c.GetFirewallRuleContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetFirewallRuleContext(ctx context.Context, id string, params FirewallRuleGetParams) (FirewallRule, error) {
+	params.Context = ctx
+
+	firewallRule := FirewallRule{}
+
+	url := fmt.Sprintf("/firewallRules/%s", id)
+	_, err := c.Request("GET", url, nil, &firewallRule, params.RequestParams.withOperation("GetFirewallRule"))
+
+	return firewallRule, err
+}
+
+func (c Client) GetFirewallRules(params FirewallRuleListParams) ([]FirewallRule, error) {
+	return c.GetFirewallRulesContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetFirewallRulesContext(ctx context.Context, params FirewallRuleListParams) ([]FirewallRule, error) {
+	params.Context = ctx
+
+	var firewallRules []FirewallRule
+
+	url := "/firewallRules"
+	_, err := c.Request("GET", url, params, &firewallRules, params.RequestParams.withOperation("GetFirewallRules"))
+
+	return firewallRules, err
+}
+
+func (c Client) UpdateFirewallRule(id string, params FirewallRuleUpdateParams) (FirewallRule, error) {
+	return c.UpdateFirewallRuleContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) UpdateFirewallRuleContext(ctx context.Context, id string, params FirewallRuleUpdateParams) (FirewallRule, error) {
+	params.Context = ctx
+
+	firewallRule := FirewallRule{}
+	if params.PortRanges == nil && (params.Protocol == FirewallProtocolICMP || params.Protocol == FirewallProtocolAll) {
+		params.PortRanges = PortRanges()
+	}
+	if params.PortRanges != nil && *params.PortRanges == nil {
+		params.PortRanges = PortRanges()
+	}
+	if err := validateFirewallRule(params.Protocol, params.PortRanges, params.SourceCIDRs); err != nil {
+		return firewallRule, err
+	}
+
+	url := fmt.Sprintf("/firewallRules/%s", id)
+	_, err := c.Request("PATCH", url, params, &firewallRule, params.RequestParams.withOperation("UpdateFirewallRule"))
+
+	return firewallRule, err
+}
+
+func (c Client) DeleteFirewallRule(id string, params FirewallRuleDeleteParams) error {
+	return c.DeleteFirewallRuleContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) DeleteFirewallRuleContext(ctx context.Context, id string, params FirewallRuleDeleteParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/firewallRules/%s", id)
+	_, err := c.Request("DELETE", url, nil, nil, params.RequestParams.withOperation("DeleteFirewallRule"))
+
+	return err
+}
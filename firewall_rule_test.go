@@ -0,0 +1,92 @@
+package paperspace
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestFirewallRuleCreateParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params FirewallRuleCreateParams
+		valid  bool
+	}{
+		{"tcp", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, PortRanges: []PortRange{Port(22), {From: 8000, To: 8080}}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, true},
+		{"udp", FirewallRuleCreateParams{Protocol: FirewallProtocolUDP, PortRanges: []PortRange{Port(53)}, SourceCIDRs: []string{"10.0.0.0/8"}, NetworkID: "nw1"}, true},
+		{"icmp", FirewallRuleCreateParams{Protocol: FirewallProtocolICMP, SourceCIDRs: []string{"10.0.0.0/8"}, MachineID: "ps1"}, true},
+		{"all", FirewallRuleCreateParams{Protocol: FirewallProtocolAll, SourceCIDRs: []string{"::/0"}, MachineID: "ps1"}, true},
+		{"tcp without ports", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"udp without ports", FirewallRuleCreateParams{Protocol: FirewallProtocolUDP, PortRanges: []PortRange{}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"icmp with ports", FirewallRuleCreateParams{Protocol: FirewallProtocolICMP, PortRanges: []PortRange{Port(22)}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"all with ports", FirewallRuleCreateParams{Protocol: FirewallProtocolAll, PortRanges: []PortRange{Port(22)}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"no protocol", FirewallRuleCreateParams{PortRanges: []PortRange{Port(22)}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"unknown protocol", FirewallRuleCreateParams{Protocol: "sctp", PortRanges: []PortRange{Port(22)}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"port 0", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, PortRanges: []PortRange{Port(0)}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"port above 65535", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, PortRanges: []PortRange{{From: 65000, To: 65536}}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"reversed range", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, PortRanges: []PortRange{{From: 8080, To: 8000}}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1"}, false},
+		{"no source CIDRs", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, PortRanges: []PortRange{Port(22)}, MachineID: "ps1"}, false},
+		{"invalid source CIDR", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, PortRanges: []PortRange{Port(22)}, SourceCIDRs: []string{"10.0.0.0"}, MachineID: "ps1"}, false},
+		{"no machine or network", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, PortRanges: []PortRange{Port(22)}, SourceCIDRs: []string{"0.0.0.0/0"}}, false},
+		{"machine and network", FirewallRuleCreateParams{Protocol: FirewallProtocolTCP, PortRanges: []PortRange{Port(22)}, SourceCIDRs: []string{"0.0.0.0/0"}, MachineID: "ps1", NetworkID: "nw1"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.params.validate()
+			if test.valid && err != nil {
+				t.Errorf("got error %v, want none", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
+
+func TestUpdateFirewallRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		params FirewallRuleUpdateParams
+		body   string
+		valid  bool
+	}{
+		{"description", FirewallRuleUpdateParams{Description: "ssh"}, `{"description":"ssh"}`, true},
+		{"replace ports", FirewallRuleUpdateParams{PortRanges: PortRanges(Port(22))}, `{"portRanges":[{"from":22,"to":22}]}`, true},
+		{"clear ports", FirewallRuleUpdateParams{PortRanges: PortRanges()}, `{"portRanges":[]}`, true},
+		{"clear ports with a nil slice", FirewallRuleUpdateParams{PortRanges: new([]PortRange)}, `{"portRanges":[]}`, true},
+		{"icmp clears ports", FirewallRuleUpdateParams{Protocol: FirewallProtocolICMP}, `{"protocol":"icmp","portRanges":[]}`, true},
+		{"tcp keeps ports", FirewallRuleUpdateParams{Protocol: FirewallProtocolTCP}, `{"protocol":"tcp"}`, true},
+		{"replace source CIDRs", FirewallRuleUpdateParams{SourceCIDRs: Strings("10.0.0.0/8")}, `{"sourceCidrs":["10.0.0.0/8"]}`, true},
+		{"all with ports", FirewallRuleUpdateParams{Protocol: FirewallProtocolAll, PortRanges: PortRanges(Port(22))}, "", false},
+		{"tcp clearing ports", FirewallRuleUpdateParams{Protocol: FirewallProtocolTCP, PortRanges: PortRanges()}, "", false},
+		{"clear source CIDRs", FirewallRuleUpdateParams{SourceCIDRs: Strings()}, "", false},
+		{"invalid port range", FirewallRuleUpdateParams{PortRanges: PortRanges(PortRange{From: 10, To: 1})}, "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body []byte
+			backend := BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+				var err error
+				body, err = json.Marshal(params)
+				return &http.Response{StatusCode: http.StatusOK}, err
+			})
+			client := NewClient(WithAPIKey("test"), WithBackend(backend))
+
+			_, err := client.UpdateFirewallRule("fw1", test.params)
+			if !test.valid {
+				if err == nil || body != nil {
+					t.Errorf("got error %v after sending %s, want an error and no request", err, body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != test.body {
+				t.Errorf("got body %s, want %s", body, test.body)
+			}
+		})
+	}
+}
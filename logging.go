@@ -0,0 +1,197 @@
+package paperspace
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const redactedValue = "[REDACTED]"
+
+var RedactedHeaders = []string{
+	"x-api-key",
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// RedactedFields are JSON keys whose values are never written to the logs,
+// matched case-insensitively at any depth of a request or response body
+var RedactedFields = []string{
+	"apiKey",
+	"apiToken",
+	"clusterSecret",
+	"secretKey",
+	"secretAccessKey",
+	"artifactsSecretAccessKey",
+	"password",
+	"containerRegistryPassword",
+}
+
+func (c *APIBackend) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	if c.Debug {
+		return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	return nil
+}
+
+func (c *APIBackend) logRequest(req *http.Request, body []byte, attempt int) {
+	logger := c.logger()
+	if logger == nil || !c.Debug {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Any("headers", redactHeaders(req.Header)),
+	}
+	if c.DebugBody {
+		attrs = append(attrs, slog.String("body", redactBody(body)))
+	}
+
+	logger.LogAttrs(req.Context(), slog.LevelDebug, "paperspace request", attrs...)
+}
+
+func (c *APIBackend) logResponse(req *http.Request, res *http.Response, body []byte, attempt int) {
+	logger := c.logger()
+	if logger == nil || !c.Debug {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Int("status", res.StatusCode),
+		slog.Any("headers", redactHeaders(res.Header)),
+	}
+	if c.DebugBody {
+		attrs = append(attrs, slog.String("body", redactBody(body)))
+	}
+
+	logger.LogAttrs(req.Context(), slog.LevelDebug, "paperspace response", attrs...)
+}
+
+func (c *APIBackend) logAttempt(req *http.Request, res *http.Response, attempt int, latency time.Duration, err error) {
+	logger := c.logger()
+	if logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+	}
+	if res != nil {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+		if requestID := responseRequestID(res); requestID != "" {
+			attrs = append(attrs, slog.String("request_id", requestID))
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(req.Context(), slog.LevelWarn, "paperspace request failed", attrs...)
+		return
+	}
+
+	logger.LogAttrs(req.Context(), slog.LevelDebug, "paperspace request completed", attrs...)
+}
+
+func (c *APIBackend) logRetry(ctx context.Context, method string, path string, attempt int, wait time.Duration) {
+	logger := c.logger()
+	if logger == nil {
+		return
+	}
+
+	logger.LogAttrs(ctx, slog.LevelInfo, "paperspace request retrying",
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.Int("attempt", attempt),
+		slog.Duration("wait", wait),
+	)
+}
+
+func responseRequestID(res *http.Response) string {
+	for _, header := range RequestIDHeaders {
+		if requestID := res.Header.Get(header); requestID != "" {
+			return requestID
+		}
+	}
+
+	return ""
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key, values := range header {
+		headers[key] = strings.Join(values, ", ")
+		for _, redactedHeader := range RedactedHeaders {
+			if strings.EqualFold(key, redactedHeader) {
+				headers[key] = redactedValue
+				break
+			}
+		}
+	}
+
+	return headers
+}
+
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return truncateErrorMessage(string(body))
+	}
+
+	redactedBody, err := json.Marshal(redactJSONValue(value))
+	if err != nil {
+		return redactedValue
+	}
+
+	return string(redactedBody)
+}
+
+func redactJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key := range value {
+			if isRedactedField(key) {
+				value[key] = redactedValue
+			} else {
+				value[key] = redactJSONValue(value[key])
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = redactJSONValue(value[i])
+		}
+	}
+
+	return value
+}
+
+func isRedactedField(key string) bool {
+	for _, field := range RedactedFields {
+		if strings.EqualFold(key, field) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package paperspace
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugLoggingRedactsCredentials(t *testing.T) {
+	secrets := []string{
+		"secret-api-key",
+		"secret-cluster",
+		"secret-key",
+		"secret-registry-password",
+		"secret-nested-key",
+		"secret-response-cluster",
+		"secret-response-password",
+		"secret-cookie",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		w.Write([]byte(`{"id":"cl123","clusterSecret":"secret-response-cluster",` +
+			`"registry":{"containerRegistryPassword":"secret-response-password","username":"registry-user"}}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient(
+		WithAPIKey("secret-api-key"),
+		WithBaseURL(server.URL),
+		WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	)
+	apiBackend := client.Backend.(*APIBackend)
+	apiBackend.Debug = true
+	apiBackend.DebugBody = true
+
+	params := map[string]interface{}{
+		"name":                      "cluster-name",
+		"clusterSecret":             "secret-cluster",
+		"containerRegistryPassword": "secret-registry-password",
+		"s3Attributes": map[string]interface{}{
+			"SecretKey": "secret-key",
+		},
+		"workers": []interface{}{
+			map[string]interface{}{"secretAccessKey": "secret-nested-key"},
+		},
+	}
+	if _, err := client.Request("POST", "/clusters/updateCluster", params, nil, RequestParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	output := buf.String()
+	for _, secret := range secrets {
+		if strings.Contains(output, secret) {
+			t.Errorf("got %s in the debug output:\n%s", secret, output)
+		}
+	}
+
+	for _, logged := range []string{"cluster-name", "registry-user", "X-Api-Key", redactedValue} {
+		if !strings.Contains(output, logged) {
+			t.Errorf("got no %s in the debug output:\n%s", logged, output)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package paperspace
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -11,6 +12,12 @@ const (
 	MachineStateOff          MachineState = "off"
 	MachineStateProvisioning MachineState = "provisioning"
 	MachineStateRunning      MachineState = "running"
+	MachineStateStarting     MachineState = "starting"
+	MachineStateStopping     MachineState = "stopping"
+	MachineStateRestarting   MachineState = "restarting"
+	MachineStateServiceReady MachineState = "serviceready"
+	MachineStateUpgrading    MachineState = "upgrading"
+	MachineStateReady        MachineState = "ready"
 )
 
 type Machine struct {
@@ -81,6 +88,18 @@ type MachineListParams struct {
 	Filter Filter `json:"filter,omitempty"`
 }
 
+type MachineRestartParams struct {
+	RequestParams
+}
+
+type MachineStartParams struct {
+	RequestParams
+}
+
+type MachineStopParams struct {
+	RequestParams
+}
+
 type MachineUpdateAttributeParams struct {
 	RequestParams
 
@@ -105,44 +124,119 @@ func NewMachineListParams() MachineListParams {
 }
 
 func (c Client) CreateMachine(params MachineCreateParams) (Machine, error) {
+	return c.CreateMachineContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) CreateMachineContext(ctx context.Context, params MachineCreateParams) (Machine, error) {
+	params.Context = ctx
+
 	machine := Machine{}
 
+	if c.PreflightValidation {
+		if err := c.validateMachineType(ctx, params.Region, params.MachineType, params.RequestParams); err != nil {
+			return machine, err
+		}
+	}
+
 	url := fmt.Sprintf("/machines/createSingleMachinePublic")
-	_, err := c.Request("POST", url, params, &machine, params.RequestParams)
+	_, err := c.Request("POST", url, params, &machine, params.RequestParams.withOperation("CreateMachine"))
 
 	return machine, err
 }
 
 func (c Client) GetMachine(id string, params MachineGetParams) (Machine, error) {
+	return c.GetMachineContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetMachineContext(ctx context.Context, id string, params MachineGetParams) (Machine, error) {
+	params.Context = ctx
+
 	machine := Machine{}
 
 	url := fmt.Sprintf("/machines/getMachinePublic?machineId=%s", id)
-	_, err := c.Request("GET", url, nil, &machine, params.RequestParams)
+	_, err := c.Request("GET", url, nil, &machine, params.RequestParams.withOperation("GetMachine"))
 
 	return machine, err
 }
 
 func (c Client) GetMachines(params MachineListParams) ([]Machine, error) {
+	return c.GetMachinesContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetMachinesContext(ctx context.Context, params MachineListParams) ([]Machine, error) {
+	params.Context = ctx
+
 	var machines []Machine
 
 	url := fmt.Sprintf("/machines/getMachines")
-	_, err := c.Request("GET", url, params, &machines, params.RequestParams)
+	_, err := c.Request("GET", url, params, &machines, params.RequestParams.withOperation("GetMachines"))
 
 	return machines, err
 }
 
 func (c Client) UpdateMachine(params MachineUpdateParams) (Machine, error) {
+	return c.UpdateMachineContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) UpdateMachineContext(ctx context.Context, params MachineUpdateParams) (Machine, error) {
+	params.Context = ctx
+
 	machine := Machine{}
 
 	url := fmt.Sprintf("/machines/updateMachine")
-	_, err := c.Request("POST", url, params, &machine, params.RequestParams)
+	_, err := c.Request("POST", url, params, &machine, params.RequestParams.withOperation("UpdateMachine"))
 
 	return machine, err
 }
 
 func (c Client) DeleteMachine(id string, params MachineDeleteParams) error {
+	return c.DeleteMachineContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) DeleteMachineContext(ctx context.Context, id string, params MachineDeleteParams) error {
+	params.Context = ctx
+
 	url := fmt.Sprintf("/machines/%s/destroyMachine", id)
-	_, err := c.Request("POST", url, nil, nil, params.RequestParams)
+	_, err := c.Request("POST", url, nil, nil, params.RequestParams.withOperation("DeleteMachine"))
+
+	return err
+}
+
+func (c Client) StartMachine(id string, params MachineStartParams) error {
+	return c.StartMachineContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) StartMachineContext(ctx context.Context, id string, params MachineStartParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/machines/%s/start", id)
+	_, err := c.Request("POST", url, nil, nil, params.RequestParams.withOperation("StartMachine"))
+
+	return err
+}
+
+func (c Client) StopMachine(id string, params MachineStopParams) error {
+	return c.StopMachineContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) StopMachineContext(ctx context.Context, id string, params MachineStopParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/machines/%s/stop", id)
+	_, err := c.Request("POST", url, nil, nil, params.RequestParams.withOperation("StopMachine"))
+
+	return err
+}
+
+func (c Client) RestartMachine(id string, params MachineRestartParams) error {
+	return c.RestartMachineContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) RestartMachineContext(ctx context.Context, id string, params MachineRestartParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/machines/%s/restart", id)
+	_, err := c.Request("POST", url, nil, nil, params.RequestParams.withOperation("RestartMachine"))
 
 	return err
 }
@@ -0,0 +1,196 @@
+package paperspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type MachineType struct {
+	Name         string   `json:"name"`
+	Label        string   `json:"label"`
+	CPUs         int      `json:"cpus"`
+	RAM          int64    `json:"ram,string"`
+	GPU          string   `json:"gpu"`
+	GPUCount     int      `json:"gpuCount"`
+	GPUMemory    int64    `json:"gpuMemory,string"`
+	HourlyPrice  Money    `json:"hourlyPrice"`
+	MonthlyPrice Money    `json:"monthlyPrice"`
+	Regions      []string `json:"regions"`
+}
+
+// UnmarshalJSON accepts RAM and GPUMemory given as JSON strings or numbers
+func (t *MachineType) UnmarshalJSON(data []byte) error {
+	type machineType MachineType
+	var raw struct {
+		machineType
+		RAM       jsonInt64 `json:"ram"`
+		GPUMemory jsonInt64 `json:"gpuMemory"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*t = MachineType(raw.machineType)
+	t.RAM = int64(raw.RAM)
+	t.GPUMemory = int64(raw.GPUMemory)
+
+	return nil
+}
+
+// jsonInt64 decodes an integer given as a JSON string or number
+type jsonInt64 int64
+
+func (i *jsonInt64) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			*i = 0
+			return nil
+		}
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", data)
+	}
+
+	*i = jsonInt64(n)
+	return nil
+}
+
+func (t MachineType) SupportsRegion(region string) bool {
+	for _, supportedRegion := range t.Regions {
+		if supportedRegion == region {
+			return true
+		}
+	}
+
+	return false
+}
+
+type MachineAvailability struct {
+	Available bool `json:"available"`
+}
+
+type MachineAvailabilityParams struct {
+	RequestParams
+}
+
+type MachineTypeListParams struct {
+	RequestParams
+
+	Region string `json:"region,omitempty"`
+}
+
+// PreflightError is returned by CreateMachine and CreateAutoscalingGroup when
+// Client.PreflightValidation is set and the machine type can't be created
+type PreflightError struct {
+	MachineType string
+	Region      string
+	Reason      string
+}
+
+func (e PreflightError) Error() string {
+	if e.Region == "" {
+		return fmt.Sprintf("machine type %s %s", e.MachineType, e.Reason)
+	}
+
+	return fmt.Sprintf("machine type %s in region %s %s", e.MachineType, e.Region, e.Reason)
+}
+
+func (c Client) GetMachineTypes(params MachineTypeListParams) ([]MachineType, error) {
+	return c.GetMachineTypesContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetMachineTypesContext(ctx context.Context, params MachineTypeListParams) ([]MachineType, error) {
+	params.Context = ctx
+
+	var machineTypes []MachineType
+
+	url := "/machines/getMachineTypes"
+	_, err := c.Request("GET", url, params, &machineTypes, params.RequestParams.withOperation("GetMachineTypes"))
+
+	return machineTypes, err
+}
+
+// CheckMachineAvailability reports whether machines of the type can currently be created in the region
+func (c Client) CheckMachineAvailability(region string, machineType string, params MachineAvailabilityParams) (bool, error) {
+	return c.CheckMachineAvailabilityContext(params.RequestParams.contextOrBackground(), region, machineType, params)
+}
+
+func (c Client) CheckMachineAvailabilityContext(ctx context.Context, region string, machineType string, params MachineAvailabilityParams) (bool, error) {
+	params.Context = ctx
+
+	availability := MachineAvailability{}
+
+	query := url.Values{"region": {region}, "machineType": {machineType}}
+	url := fmt.Sprintf("/machines/getAvailability?%s", query.Encode())
+	_, err := c.Request("GET", url, nil, &availability, params.RequestParams.withOperation("CheckMachineAvailability"))
+
+	return availability.Available, err
+}
+
+// validateMachineType checks that the machine type exists and, when region is
+// given, that it is offered and available there
+func (c Client) validateMachineType(ctx context.Context, region string, machineTypeName string, requestParams RequestParams) error {
+	requestParams.Context = ctx
+	requestParams.Operation = ""
+
+	machineTypes, err := c.GetMachineTypesContext(ctx, MachineTypeListParams{RequestParams: requestParams})
+	if err != nil {
+		return err
+	}
+
+	var machineType *MachineType
+	for i := range machineTypes {
+		if machineTypes[i].Name == machineTypeName {
+			machineType = &machineTypes[i]
+			break
+		}
+	}
+	if machineType == nil {
+		return PreflightError{MachineType: machineTypeName, Reason: "does not exist"}
+	}
+
+	if region == "" {
+		return nil
+	}
+
+	resolvedRegion, err := c.ResolveRegionContext(ctx, region, RegionListParams{RequestParams: requestParams})
+	if err != nil {
+		return err
+	}
+	if !resolvedRegion.IsAvailable {
+		return PreflightError{MachineType: machineTypeName, Region: region, Reason: "is in an unavailable region"}
+	}
+	if len(resolvedRegion.MachineTypes) > 0 && !resolvedRegion.SupportsMachineType(machineTypeName) {
+		return PreflightError{MachineType: machineTypeName, Region: region, Reason: "is not offered"}
+	}
+	if len(machineType.Regions) > 0 && !machineType.SupportsRegion(resolvedRegion.Name) && !machineType.SupportsRegion(resolvedRegion.Code) {
+		return PreflightError{MachineType: machineTypeName, Region: region, Reason: "is not offered"}
+	}
+
+	available, err := c.CheckMachineAvailabilityContext(ctx, region, machineTypeName, MachineAvailabilityParams{RequestParams: requestParams})
+	if err != nil {
+		return err
+	}
+	if !available {
+		return PreflightError{MachineType: machineTypeName, Region: region, Reason: "is not available"}
+	}
+
+	return nil
+}
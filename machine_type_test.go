@@ -0,0 +1,113 @@
+package paperspace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMachineTypeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		ram       int64
+		gpuMemory int64
+	}{
+		{"strings", `{"name":"P4000","ram":"32212254720","gpuMemory":"8589934592"}`, 32212254720, 8589934592},
+		{"numbers", `{"name":"P4000","ram":32212254720,"gpuMemory":8589934592}`, 32212254720, 8589934592},
+		{"empty and null", `{"name":"C5","ram":"","gpuMemory":null}`, 0, 0},
+		{"missing", `{"name":"C5"}`, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var machineType MachineType
+			if err := json.Unmarshal([]byte(test.body), &machineType); err != nil {
+				t.Fatal(err)
+			}
+			if machineType.Name == "" || machineType.RAM != test.ram || machineType.GPUMemory != test.gpuMemory {
+				t.Errorf("got %s with RAM %d and GPU memory %d, want RAM %d and GPU memory %d",
+					machineType.Name, machineType.RAM, machineType.GPUMemory, test.ram, test.gpuMemory)
+			}
+		})
+	}
+
+	var machineType MachineType
+	if err := json.Unmarshal([]byte(`{"ram":"32GB"}`), &machineType); err == nil {
+		t.Error("got no error for RAM that isn't a number")
+	}
+}
+
+func TestValidateMachineType(t *testing.T) {
+	const machineTypes = `[{"name":"C5","regions":["East Coast (NY2)"]},{"name":"P4000"}]`
+	const regions = `[
+		{"id":1,"name":"East Coast (NY2)","code":"NY2","isAvailable":true},
+		{"id":2,"name":"West Coast (CA1)","code":"CA1","isAvailable":true,"machineTypes":["P4000"]},
+		{"id":3,"name":"Europe (AMS1)","code":"AMS1","isAvailable":false}
+	]`
+
+	tests := []struct {
+		name         string
+		region       string
+		machineType  string
+		machineTypes string
+		available    bool
+		reason       string
+		err          func(error) bool
+	}{
+		{name: "no region", machineType: "C5"},
+		{name: "available", region: "NY2", machineType: "C5", available: true},
+		{name: "listing fails", machineType: "C5", machineTypes: "error", err: IsServerError},
+		{name: "unknown machine type", machineType: "C9", reason: "does not exist"},
+		{name: "unknown region", region: "SG1", machineType: "C5", err: IsNotFound},
+		{name: "unavailable region", region: "AMS1", machineType: "P4000", reason: "is in an unavailable region"},
+		{name: "not offered by the region", region: "CA1", machineType: "C5", reason: "is not offered"},
+		{name: "region not offered by the machine type", region: "NY2", machineType: "C5", machineTypes: `[{"name":"C5","regions":["CA1"]}]`, reason: "is not offered"},
+		{name: "not available", region: "NY2", machineType: "P4000", reason: "is not available"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			backend := BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+				body := regions
+				switch {
+				case strings.HasPrefix(url, "/machines/getMachineTypes"):
+					body = machineTypes
+					if test.machineTypes == "error" {
+						return nil, &APIError{StatusCode: http.StatusServiceUnavailable, Method: method, URL: url}
+					}
+					if test.machineTypes != "" {
+						body = test.machineTypes
+					}
+				case strings.HasPrefix(url, "/machines/getAvailability"):
+					body = `{"available":false}`
+					if test.available {
+						body = `{"available":true}`
+					}
+				}
+
+				return &http.Response{StatusCode: http.StatusOK}, json.Unmarshal([]byte(body), result)
+			})
+			client := NewClient(WithAPIKey("test"), WithBackend(backend))
+
+			err := client.validateMachineType(context.Background(), test.region, test.machineType, RequestParams{})
+
+			var preflightError PreflightError
+			switch {
+			case test.reason != "":
+				if !errors.As(err, &preflightError) || preflightError.Reason != test.reason {
+					t.Errorf("got error %v, want a preflight error that it %s", err, test.reason)
+				}
+			case test.err != nil:
+				if !test.err(err) {
+					t.Errorf("got error %v", err)
+				}
+			case err != nil:
+				t.Errorf("got error %v, want none", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package paperspace
+
+import (
+	"context"
+	"fmt"
+)
+
+// MachineUser is a team member with access to a machine
+type MachineUser struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstname"`
+	LastName  string `json:"lastname"`
+	TeamID    string `json:"teamId"`
+}
+
+type MachineUserAddParams struct {
+	RequestParams
+
+	UserID string `json:"userId"`
+}
+
+type MachineUserListParams struct {
+	RequestParams
+}
+
+type MachineUserRemoveParams struct {
+	RequestParams
+
+	UserID string `json:"userId"`
+}
+
+// AddMachineUser gives a member of the machine's team access to the machine
+func (c Client) AddMachineUser(machineID string, params MachineUserAddParams) error {
+	return c.AddMachineUserContext(params.RequestParams.contextOrBackground(), machineID, params)
+}
+
+func (c Client) AddMachineUserContext(ctx context.Context, machineID string, params MachineUserAddParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/machines/%s/addUser", machineID)
+	_, err := c.Request("POST", url, params, nil, params.RequestParams.withOperation("AddMachineUser"))
+
+	return err
+}
+
+func (c Client) RemoveMachineUser(machineID string, params MachineUserRemoveParams) error {
+	return c.RemoveMachineUserContext(params.RequestParams.contextOrBackground(), machineID, params)
+}
+
+func (c Client) RemoveMachineUserContext(ctx context.Context, machineID string, params MachineUserRemoveParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/machines/%s/removeUser", machineID)
+	_, err := c.Request("POST", url, params, nil, params.RequestParams.withOperation("RemoveMachineUser"))
+
+	return err
+}
+
+func (c Client) GetMachineUsers(machineID string, params MachineUserListParams) ([]MachineUser, error) {
+	return c.GetMachineUsersContext(params.RequestParams.contextOrBackground(), machineID, params)
+}
+
+func (c Client) GetMachineUsersContext(ctx context.Context, machineID string, params MachineUserListParams) ([]MachineUser, error) {
+	params.Context = ctx
+
+	var users []MachineUser
+
+	url := fmt.Sprintf("/machines/%s/users", machineID)
+	_, err := c.Request("GET", url, nil, &users, params.RequestParams.withOperation("GetMachineUsers"))
+
+	return users, err
+}
@@ -0,0 +1,99 @@
+package paperspace
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Middleware wraps the Backend used by Client. Middleware registered first
+// runs outermost and sees every request before the ones registered after it.
+type Middleware func(next Backend) Backend
+
+func chainMiddleware(backend Backend, middleware []Middleware) Backend {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		backend = middleware[i](backend)
+	}
+
+	return backend
+}
+
+// HeaderMiddleware sets the given headers on every request, replacing headers
+// of the same name set by the caller, such as x-api-key, whatever their case
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return func(next Backend) Backend {
+		return BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+			requestHeaders := make(map[string]string, len(requestParams.Headers)+len(headers))
+			for key, value := range requestParams.Headers {
+				if !hasHeader(headers, key) {
+					requestHeaders[key] = value
+				}
+			}
+			for key, value := range headers {
+				requestHeaders[key] = value
+			}
+			requestParams.Headers = requestHeaders
+
+			return next.Request(method, url, params, result, requestParams)
+		})
+	}
+}
+
+// hasHeader reports whether headers has name in any case
+func hasHeader(headers map[string]string, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoggingMiddleware logs the method, path, status and duration of every
+// request to logger, or to slog.Default when logger is nil
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Backend) Backend {
+		return BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.Request(method, url, params, result, requestParams)
+
+			attrs := []slog.Attr{
+				slog.String("method", method),
+				slog.String("path", requestPath(url)),
+			}
+			if requestParams.Operation != "" {
+				attrs = append(attrs, slog.String("operation", requestParams.Operation))
+			}
+			if res != nil {
+				attrs = append(attrs, slog.Int("status", res.StatusCode))
+			}
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+			ctx := requestParams.contextOrBackground()
+			requestLogger := logger
+			if requestLogger == nil {
+				requestLogger = slog.Default()
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				requestLogger.LogAttrs(ctx, slog.LevelWarn, "paperspace call failed", attrs...)
+			} else {
+				requestLogger.LogAttrs(ctx, slog.LevelInfo, "paperspace call completed", attrs...)
+			}
+
+			return res, err
+		})
+	}
+}
+
+// requestPath strips the query string from url
+func requestPath(url string) string {
+	if i := strings.IndexByte(url, '?'); i >= 0 {
+		return url[:i]
+	}
+
+	return url
+}
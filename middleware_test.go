@@ -0,0 +1,87 @@
+package paperspace
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestChainMiddlewareOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Backend) Backend {
+			return BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				res, err := next.Request(method, url, params, result, requestParams)
+				calls = append(calls, name+" after")
+				return res, err
+			})
+		}
+	}
+	backend := BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+		calls = append(calls, "backend")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	client := NewClient(WithAPIKey("test"), WithBackend(backend), WithMiddleware(record("first"), record("second")))
+	client.Use(record("third"))
+
+	if _, err := client.Request("GET", "/machines/getMachines", nil, nil, RequestParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"first before", "second before", "third before", "backend", "third after", "second after", "first after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v, want %v", calls, want)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		middleware map[string]string
+		headers    map[string]string
+		want       map[string]string
+	}{
+		{
+			name:       "added",
+			middleware: map[string]string{"X-Team": "research"},
+			want:       map[string]string{"x-api-key": "test", "X-Team": "research"},
+		},
+		{
+			name:       "caller header replaced",
+			middleware: map[string]string{"X-Team": "research"},
+			headers:    map[string]string{"X-Team": "other", "X-Trace": "1"},
+			want:       map[string]string{"x-api-key": "test", "X-Team": "research", "X-Trace": "1"},
+		},
+		{
+			name:       "API key replaced",
+			middleware: map[string]string{"x-api-key": "override"},
+			want:       map[string]string{"x-api-key": "override"},
+		},
+		{
+			name:       "API key replaced in another case",
+			middleware: map[string]string{"X-Api-Key": "override"},
+			headers:    map[string]string{"x-team": "other"},
+			want:       map[string]string{"X-Api-Key": "override", "x-team": "other"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var headers map[string]string
+			backend := BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+				headers = requestParams.Headers
+				return &http.Response{StatusCode: http.StatusOK}, nil
+			})
+			client := NewClient(WithAPIKey("test"), WithBackend(backend), WithMiddleware(HeaderMiddleware(test.middleware)))
+
+			if _, err := client.Request("GET", "/machines/getMachines", nil, nil, RequestParams{Headers: test.headers}); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(headers, test.want) {
+				t.Errorf("got headers %v, want %v", headers, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package paperspace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const nanosPerDollar = 1000000000
+
+// Money is an amount of US dollars held as a whole number of nano dollars, so
+// prices and costs add up without floating point error
+type Money struct {
+	nanos int64
+}
+
+func MoneyFromNanos(nanos int64) Money {
+	return Money{nanos: nanos}
+}
+
+// ParseMoney parses a decimal amount of dollars such as "0.45", "$1,200" or "-$3"
+func ParseMoney(s string) (Money, error) {
+	value := strings.TrimSpace(s)
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign = "-"
+		value = value[1:]
+	}
+	value = sign + strings.TrimPrefix(value, "$")
+	value = strings.Replace(value, ",", "", -1)
+
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+
+	nanos, ok := roundNanos(amount.Mul(amount, big.NewRat(nanosPerDollar, 1)))
+	if !ok {
+		return Money{}, fmt.Errorf("amount %q is out of range", s)
+	}
+
+	return Money{nanos: nanos}, nil
+}
+
+// roundNanos rounds half away from zero
+func roundNanos(nanos *big.Rat) (int64, bool) {
+	quotient, remainder := new(big.Int).QuoRem(nanos.Num(), nanos.Denom(), new(big.Int))
+
+	remainder.Abs(remainder)
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(nanos.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(nanos.Sign())))
+	}
+
+	return quotient.Int64(), quotient.IsInt64()
+}
+
+func (m Money) Nanos() int64 {
+	return m.nanos
+}
+
+func (m Money) IsZero() bool {
+	return m.nanos == 0
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{nanos: m.nanos + other.nanos}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{nanos: m.nanos - other.nanos}
+}
+
+// MulRat returns the amount multiplied by numerator/denominator, rounded half
+// away from zero to the nearest nano dollar
+func (m Money) MulRat(numerator int64, denominator int64) Money {
+	if denominator == 0 {
+		return Money{}
+	}
+
+	nanos, _ := roundNanos(new(big.Rat).Mul(big.NewRat(m.nanos, 1), big.NewRat(numerator, denominator)))
+	return Money{nanos: nanos}
+}
+
+// String formats the amount in dollars with at least two decimal places
+func (m Money) String() string {
+	nanos := m.nanos
+	sign := ""
+	if nanos < 0 {
+		sign = "-"
+		nanos = -nanos
+	}
+
+	fraction := strings.TrimRight(fmt.Sprintf("%09d", nanos%nanosPerDollar), "0")
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+
+	return fmt.Sprintf("%s%d.%s", sign, nanos/nanosPerDollar, fraction)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts amounts given as JSON strings or numbers
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		if strings.TrimSpace(value) == "" {
+			*m = Money{}
+			return nil
+		}
+	}
+
+	money, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+
+	*m = money
+	return nil
+}
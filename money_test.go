@@ -0,0 +1,152 @@
+package paperspace
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input string
+		nanos int64
+	}{
+		{"0", 0},
+		{"0.45", 450000000},
+		{"  1.5 ", 1500000000},
+		{"-3", -3000000000},
+		{"$1,200", 1200000000000},
+		{"$1,200.75", 1200750000000},
+		{"-$1,200.75", -1200750000000},
+		{"$-2", -2000000000},
+		{"0.0000000005", 1},
+		{"0.0000000004999", 0},
+		{"-0.0000000005", -1},
+		{"-0.0000000004999", 0},
+		{"1.2345678915", 1234567892},
+		{"1e-3", 1000000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			money, err := ParseMoney(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if money.Nanos() != test.nanos {
+				t.Errorf("got %d nanos, want %d", money.Nanos(), test.nanos)
+			}
+		})
+	}
+}
+
+func TestParseMoneyInvalid(t *testing.T) {
+	for _, input := range []string{"", "$", "abc", "1.2.3", "$$1", "--1", "99999999999999999999"} {
+		if _, err := ParseMoney(input); err == nil {
+			t.Errorf("got no error for %q", input)
+		}
+	}
+}
+
+func TestMoneyMulRat(t *testing.T) {
+	tests := []struct {
+		name        string
+		nanos       int64
+		numerator   int64
+		denominator int64
+		want        int64
+	}{
+		{"whole", 450000000, 2, 1, 900000000},
+		{"one second of an hourly rate", 450000000, 1, 3600, 125000},
+		{"half rounds up", 5, 1, 2, 3},
+		{"below half rounds down", 4, 1, 3, 1},
+		{"negative half rounds down", -5, 1, 2, -3},
+		{"negative numerator", 10, -1, 4, -3},
+		{"zero denominator", 10, 1, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := MoneyFromNanos(test.nanos).MulRat(test.numerator, test.denominator)
+			if got.Nanos() != test.want {
+				t.Errorf("got %d nanos, want %d", got.Nanos(), test.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		nanos int64
+		want  string
+	}{
+		{0, "0.00"},
+		{1500000000, "1.50"},
+		{-1500000000, "-1.50"},
+		{450000000, "0.45"},
+		{255000000, "0.255"},
+		{1, "0.000000001"},
+		{-1, "-0.000000001"},
+		{1200750000000, "1200.75"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := MoneyFromNanos(test.nanos).String(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		nanos int64
+		want  string
+	}{
+		{`"0.45"`, 450000000, `"0.45"`},
+		{`0.45`, 450000000, `"0.45"`},
+		{`"$1,200.5"`, 1200500000000, `"1200.50"`},
+		{`-12`, -12000000000, `"-12.00"`},
+		{`1.5e2`, 150000000000, `"150.00"`},
+		{`""`, 0, `"0.00"`},
+		{`null`, 0, `"0.00"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var money Money
+			if err := json.Unmarshal([]byte(test.input), &money); err != nil {
+				t.Fatal(err)
+			}
+			if money.Nanos() != test.nanos {
+				t.Errorf("got %d nanos, want %d", money.Nanos(), test.nanos)
+			}
+
+			data, err := json.Marshal(money)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.want {
+				t.Errorf("got %s, want %s", data, test.want)
+			}
+
+			var roundTrip Money
+			if err := json.Unmarshal(data, &roundTrip); err != nil {
+				t.Fatal(err)
+			}
+			if roundTrip != money {
+				t.Errorf("got %v after a round trip, want %v", roundTrip, money)
+			}
+		})
+	}
+}
+
+func TestMoneyUnmarshalJSONInvalid(t *testing.T) {
+	for _, input := range []string{`"abc"`, `true`, `{}`, `[1]`} {
+		var money Money
+		if err := json.Unmarshal([]byte(input), &money); err == nil {
+			t.Errorf("got no error for %s", input)
+		}
+	}
+}
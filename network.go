@@ -1,7 +1,9 @@
 package paperspace
 
 import (
+	"context"
 	"fmt"
+	"net/netip"
 	"time"
 )
 
@@ -19,8 +21,15 @@ type Network struct {
 type NetworkCreateParams struct {
 	RequestParams
 
-	Name   string `json:"name"`
-	Region string `json:"region"`
+	Name    string `json:"name"`
+	Region  string `json:"region"`
+	Network string `json:"network,omitempty"`
+	Netmask string `json:"netmask,omitempty"`
+
+	// CheckOverlap rejects the request when the range of Network and Netmask
+	// overlaps one of the team's networks or a ReservedPrefixes range
+	CheckOverlap     bool           `json:"-"`
+	ReservedPrefixes []netip.Prefix `json:"-"`
 }
 
 type networkCreateParamsInternal struct {
@@ -28,6 +37,8 @@ type networkCreateParamsInternal struct {
 
 	Name     string `json:"name"`
 	RegionID int    `json:"regionId"`
+	Network  string `json:"network,omitempty"`
+	Netmask  string `json:"netmask,omitempty"`
 }
 
 type NetworkDeleteParams struct {
@@ -38,6 +49,18 @@ type NetworkGetParams struct {
 	RequestParams
 }
 
+type NetworkMachineListParams struct {
+	RequestParams
+
+	Filter Filter `json:"filter,omitempty"`
+}
+
+type NetworkUpdateParams struct {
+	RequestParams
+
+	Name string `json:"name,omitempty"`
+}
+
 type NetworkListParams struct {
 	RequestParams
 	ID      string `json:"id,omitempty"`
@@ -49,27 +72,69 @@ type NetworkListParams struct {
 }
 
 func (c Client) CreateNetwork(params NetworkCreateParams) (Network, error) {
-	regionID, ok := RegionMap[params.Region]
-	if !ok {
-		return Network{}, fmt.Errorf("no region found for %s", params.Region)
+	return c.CreateNetworkContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) CreateNetworkContext(ctx context.Context, params NetworkCreateParams) (Network, error) {
+	params.Context = ctx
+
+	region, err := c.ResolveRegionContext(ctx, params.Region, RegionListParams{RequestParams: params.RequestParams})
+	if err != nil {
+		return Network{}, err
 	}
 
-	intParams := networkCreateParamsInternal{Name: params.Name, RegionID: regionID, RequestParams: params.RequestParams}
+	if params.CheckOverlap {
+		if err := c.checkNetworkCreateOverlap(ctx, params); err != nil {
+			return Network{}, err
+		}
+	}
+
+	intParams := networkCreateParamsInternal{
+		Name:          params.Name,
+		RegionID:      region.ID,
+		Network:       params.Network,
+		Netmask:       params.Netmask,
+		RequestParams: params.RequestParams,
+	}
 
 	network := Network{}
 	url := fmt.Sprintf("/networks")
-	_, err := c.Request("POST", url, intParams, &network, params.RequestParams)
+	_, err = c.Request("POST", url, intParams, &network, params.RequestParams.withOperation("CreateNetwork"))
 
 	return network, err
 }
 
+func (c Client) checkNetworkCreateOverlap(ctx context.Context, params NetworkCreateParams) error {
+	if params.Network == "" || params.Netmask == "" {
+		return fmt.Errorf("network and netmask are required to check network %s for overlaps", params.Name)
+	}
+
+	prefix, err := ParseNetworkPrefix(params.Network, params.Netmask)
+	if err != nil {
+		return err
+	}
+
+	networks, err := c.GetNetworksContext(ctx, NetworkListParams{RequestParams: params.RequestParams})
+	if err != nil {
+		return err
+	}
+
+	return CheckNetworkOverlap(prefix, networks, params.ReservedPrefixes)
+}
+
 func (c Client) GetNetwork(id string, params NetworkGetParams) (Network, error) {
-	networks, err := c.GetNetworks(NetworkListParams{ID: id, RequestParams: params.RequestParams})
+	return c.GetNetworkContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetNetworkContext(ctx context.Context, id string, params NetworkGetParams) (Network, error) {
+	params.Context = ctx
+
+	networks, err := c.GetNetworksContext(ctx, NetworkListParams{ID: id, RequestParams: params.RequestParams.withOperation("GetNetwork")})
 	if err != nil {
 		return Network{}, err
 	}
 	if len(networks) == 0 {
-		return Network{}, fmt.Errorf("no network found for ID %s", id)
+		return Network{}, NotFoundError{Resource: "network", Key: "ID " + id}
 	}
 	if len(networks) > 1 {
 		return Network{}, fmt.Errorf("found more than one network for ID %s", id)
@@ -78,17 +143,67 @@ func (c Client) GetNetwork(id string, params NetworkGetParams) (Network, error)
 }
 
 func (c Client) GetNetworks(params NetworkListParams) ([]Network, error) {
+	return c.GetNetworksContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetNetworksContext(ctx context.Context, params NetworkListParams) ([]Network, error) {
+	params.Context = ctx
+
 	var networks []Network
 
 	url := fmt.Sprintf("/networks")
-	_, err := c.Request("GET", url, params, &networks, params.RequestParams)
+	_, err := c.Request("GET", url, params, &networks, params.RequestParams.withOperation("GetNetworks"))
 
 	return networks, err
 }
 
 func (c Client) DeleteNetwork(id string, params NetworkDeleteParams) error {
+	return c.DeleteNetworkContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) DeleteNetworkContext(ctx context.Context, id string, params NetworkDeleteParams) error {
+	params.Context = ctx
+
 	url := fmt.Sprintf("/networks/%s", id)
-	_, err := c.Request("DELETE", url, nil, nil, params.RequestParams)
+	_, err := c.Request("DELETE", url, nil, nil, params.RequestParams.withOperation("DeleteNetwork"))
 
 	return err
 }
+
+func (c Client) UpdateNetwork(id string, params NetworkUpdateParams) (Network, error) {
+	return c.UpdateNetworkContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) UpdateNetworkContext(ctx context.Context, id string, params NetworkUpdateParams) (Network, error) {
+	params.Context = ctx
+
+	network := Network{}
+
+	url := fmt.Sprintf("/networks/%s", id)
+	_, err := c.Request("PATCH", url, params, &network, params.RequestParams.withOperation("UpdateNetwork"))
+
+	return network, err
+}
+
+// GetNetworkMachines returns the machines attached to the network, along
+// with their private IP addresses
+func (c Client) GetNetworkMachines(id string, params NetworkMachineListParams) ([]Machine, error) {
+	return c.GetNetworkMachinesContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetNetworkMachinesContext(ctx context.Context, id string, params NetworkMachineListParams) ([]Machine, error) {
+	params.Context = ctx
+
+	filter := params.Filter
+	where := make(map[string]interface{}, len(filter.Where)+1)
+	for key, value := range filter.Where {
+		where[key] = value
+	}
+	where["networkId"] = id
+	filter.Where = where
+
+	return c.GetMachinesContext(ctx, MachineListParams{
+		RequestParams: params.RequestParams.withOperation("GetNetworkMachines"),
+		Filter:        filter,
+	})
+}
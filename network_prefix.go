@@ -0,0 +1,166 @@
+package paperspace
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// NetworkOverlapError is returned when a network range collides with an
+// existing network or a reserved range
+type NetworkOverlapError struct {
+	Prefix netip.Prefix
+	// Network is the existing network overlapping Prefix, it is empty when
+	// Prefix overlaps Reserved instead
+	Network  Network
+	Reserved netip.Prefix
+}
+
+func (e NetworkOverlapError) Error() string {
+	if e.Reserved.IsValid() {
+		return fmt.Sprintf("network %s overlaps reserved range %s", e.Prefix, e.Reserved)
+	}
+
+	if e.Network.Name == "" {
+		return fmt.Sprintf("network %s overlaps network %s", e.Prefix, e.Network.ID)
+	}
+
+	return fmt.Sprintf("network %s overlaps network %s (%s)", e.Prefix, e.Network.ID, e.Network.Name)
+}
+
+// NetworkOverlap is a pair of networks whose ranges collide
+type NetworkOverlap struct {
+	Network Network
+	Other   Network
+}
+
+// Prefix parses Network and Netmask into the network's range
+func (n Network) Prefix() (netip.Prefix, error) {
+	return ParseNetworkPrefix(n.Network, n.Netmask)
+}
+
+// ParseNetworkPrefix combines an address such as 10.0.0.0 with a netmask given
+// either as 255.255.255.0 or as a prefix length such as 24
+func ParseNetworkPrefix(network string, netmask string) (netip.Prefix, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(network))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid network %q: %w", network, err)
+	}
+
+	bits, err := netmaskBits(netmask, addr.BitLen())
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return addr.Prefix(bits)
+}
+
+func netmaskBits(netmask string, bitLen int) (int, error) {
+	netmask = strings.TrimPrefix(strings.TrimSpace(netmask), "/")
+
+	if bits, err := strconv.Atoi(netmask); err == nil {
+		if bits < 0 || bits > bitLen {
+			return 0, fmt.Errorf("invalid netmask %q", netmask)
+		}
+		return bits, nil
+	}
+
+	mask, err := netip.ParseAddr(netmask)
+	if err != nil || mask.BitLen() != bitLen {
+		return 0, fmt.Errorf("invalid netmask %q", netmask)
+	}
+
+	// a valid mask is a run of ones followed only by zeros
+	bits := 0
+	seenZero := false
+	for _, b := range mask.AsSlice() {
+		for i := 7; i >= 0; i-- {
+			if b&(1<<uint(i)) == 0 {
+				seenZero = true
+			} else if seenZero {
+				return 0, fmt.Errorf("invalid netmask %q", netmask)
+			} else {
+				bits++
+			}
+		}
+	}
+
+	return bits, nil
+}
+
+// FindNetworkOverlaps returns every pair of networks whose ranges collide,
+// ignoring deleted networks and networks without a range. Networks with a
+// range that can't be parsed are skipped and reported in the returned error,
+// alongside the overlaps found between the others.
+func FindNetworkOverlaps(networks []Network) ([]NetworkOverlap, error) {
+	networks, prefixes, err := networkPrefixes(networks)
+
+	var overlaps []NetworkOverlap
+	for i := range networks {
+		for j := i + 1; j < len(networks); j++ {
+			if prefixes[i].Overlaps(prefixes[j]) {
+				overlaps = append(overlaps, NetworkOverlap{Network: networks[i], Other: networks[j]})
+			}
+		}
+	}
+
+	return overlaps, err
+}
+
+// CheckNetworkOverlap returns a NetworkOverlapError when prefix collides with
+// one of the networks or with a reserved range. Deleted networks and networks
+// whose range is missing or can't be parsed are ignored.
+func CheckNetworkOverlap(prefix netip.Prefix, networks []Network, reserved []netip.Prefix) error {
+	for _, reservedPrefix := range reserved {
+		if prefix.Overlaps(reservedPrefix) {
+			return NetworkOverlapError{Prefix: prefix, Reserved: reservedPrefix}
+		}
+	}
+
+	networks, prefixes, _ := networkPrefixes(networks)
+	for i, network := range networks {
+		if prefix.Overlaps(prefixes[i]) {
+			return NetworkOverlapError{Prefix: prefix, Network: network}
+		}
+	}
+
+	return nil
+}
+
+// networkPrefixes returns the active networks that have a valid range along
+// with their ranges, and an error naming the networks whose range is invalid
+func networkPrefixes(networks []Network) ([]Network, []netip.Prefix, error) {
+	var validNetworks []Network
+	var prefixes []netip.Prefix
+	var errs []error
+
+	for _, network := range activeNetworks(networks) {
+		if network.Network == "" && network.Netmask == "" {
+			continue
+		}
+
+		prefix, err := network.Prefix()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("network %s: %w", network.ID, err))
+			continue
+		}
+
+		validNetworks = append(validNetworks, network)
+		prefixes = append(prefixes, prefix)
+	}
+
+	return validNetworks, prefixes, errors.Join(errs...)
+}
+
+func activeNetworks(networks []Network) []Network {
+	active := make([]Network, 0, len(networks))
+	for _, network := range networks {
+		if network.DtDeleted.IsZero() {
+			active = append(active, network)
+		}
+	}
+
+	return active
+}
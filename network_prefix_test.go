@@ -0,0 +1,48 @@
+package paperspace
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+var testNetworks = []Network{
+	{ID: "nw1", Network: "10.0.0.0", Netmask: "255.255.255.0"},
+	{ID: "nw2", Network: "", Netmask: ""},
+	{ID: "nw3", Network: "10.0.x.0", Netmask: "24"},
+	{ID: "nw4", Network: "10.0.0.128", Netmask: "25"},
+	{ID: "nw5", Network: "10.1.0.0", Netmask: ""},
+}
+
+func TestCheckNetworkOverlapSkipsInvalidNetworks(t *testing.T) {
+	err := CheckNetworkOverlap(netip.MustParsePrefix("10.2.0.0/16"), testNetworks, nil)
+	if err != nil {
+		t.Errorf("got error %v, want no overlap", err)
+	}
+
+	err = CheckNetworkOverlap(netip.MustParsePrefix("10.0.0.0/16"), testNetworks, nil)
+	var overlapError NetworkOverlapError
+	if !errors.As(err, &overlapError) || overlapError.Network.ID != "nw1" {
+		t.Errorf("got error %v, want an overlap with nw1", err)
+	}
+}
+
+func TestFindNetworkOverlapsSkipsInvalidNetworks(t *testing.T) {
+	overlaps, err := FindNetworkOverlaps(testNetworks)
+
+	if len(overlaps) != 1 || overlaps[0].Network.ID != "nw1" || overlaps[0].Other.ID != "nw4" {
+		t.Errorf("got overlaps %v, want nw1 and nw4", overlaps)
+	}
+	if err == nil {
+		t.Fatal("got no error, want the invalid networks reported")
+	}
+	for _, id := range []string{"nw3", "nw5"} {
+		if !strings.Contains(err.Error(), id) {
+			t.Errorf("got error %q, want it to name %s", err, id)
+		}
+	}
+	if strings.Contains(err.Error(), "nw2") {
+		t.Errorf("got error %q, want networks without a range ignored", err)
+	}
+}
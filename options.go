@@ -0,0 +1,221 @@
+package paperspace
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	apiKey          string
+	baseURL         string
+	httpClient      *http.Client
+	timeout         time.Duration
+	retryPolicy     *RetryPolicy
+	rateLimiter     *RateLimiter
+	userAgentSuffix string
+	logger          *slog.Logger
+	backend         Backend
+	middleware      []Middleware
+	loadEnv         bool
+	preflight       bool
+
+	credentialProvider CredentialProvider
+	profile            string
+}
+
+// WithEnv reads PAPERSPACE_APIKEY, PAPERSPACE_BASEURL, PAPERSPACE_DEBUG and
+// PAPERSPACE_DEBUG_BODY, then falls back to the profile named by
+// PAPERSPACE_PROFILE in the config file. Values given by other options take
+// precedence.
+func WithEnv() Option {
+	return func(o *clientOptions) {
+		o.loadEnv = true
+	}
+}
+
+func WithAPIKey(apiKey string) Option {
+	return func(o *clientOptions) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithProfile reads the API key and base URL from a profile of
+// ~/.paperspace/config.json, or of PAPERSPACE_CONFIG_FILE when WithEnv is also
+// given. The profile takes precedence over PAPERSPACE_APIKEY. Use
+// NewValidatedClient to find out when the profile can't be read.
+func WithProfile(profile string) Option {
+	return func(o *clientOptions) {
+		o.profile = profile
+	}
+}
+
+// WithCredentialProvider replaces the environment and config file lookups,
+// WithAPIKey and WithBaseURL still take precedence
+func WithCredentialProvider(credentialProvider CredentialProvider) Option {
+	return func(o *clientOptions) {
+		o.credentialProvider = credentialProvider
+	}
+}
+
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by the APIBackend
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+func WithRetryPolicy(retryPolicy *RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = retryPolicy
+	}
+}
+
+func WithRateLimiter(rateLimiter *RateLimiter) Option {
+	return func(o *clientOptions) {
+		o.rateLimiter = rateLimiter
+	}
+}
+
+// WithUserAgentSuffix appends suffix to the default User-Agent header
+func WithUserAgentSuffix(suffix string) Option {
+	return func(o *clientOptions) {
+		o.userAgentSuffix = suffix
+	}
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithBackend replaces the APIBackend, the HTTP client, timeout, retry policy,
+// rate limiter, user agent and logger options are ignored when it is used
+func WithBackend(backend Backend) Option {
+	return func(o *clientOptions) {
+		o.backend = backend
+	}
+}
+
+// WithPreflightValidation sets Client.PreflightValidation
+func WithPreflightValidation() Option {
+	return func(o *clientOptions) {
+		o.preflight = true
+	}
+}
+
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *clientOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+func newClientOptions(opts []Option) clientOptions {
+	if len(opts) == 0 {
+		opts = []Option{WithEnv()}
+	}
+
+	options := clientOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+func (o clientOptions) credentialChain() ChainCredentialProvider {
+	providers := ChainCredentialProvider{}
+	if o.apiKey != "" || o.baseURL != "" {
+		providers = append(providers, StaticCredentialProvider{APIKey: o.apiKey, BaseURL: o.baseURL})
+	}
+
+	switch {
+	case o.credentialProvider != nil:
+		providers = append(providers, o.credentialProvider)
+	case o.loadEnv && o.profile != "":
+		// A profile named in code is more specific than PAPERSPACE_APIKEY
+		providers = append(providers, ConfigFileCredentialProvider{Profile: o.profile}, EnvCredentialProvider{})
+	case o.loadEnv:
+		providers = append(providers, EnvCredentialProvider{}, ConfigFileCredentialProvider{})
+	case o.profile != "":
+		providers = append(providers, ConfigFileCredentialProvider{Profile: o.profile, IgnoreEnv: true})
+	}
+
+	return providers
+}
+
+func (o clientOptions) apiBackend(credentials Credentials) *APIBackend {
+	apiBackend := newAPIBackend()
+	if o.loadEnv {
+		apiBackend.loadEnv()
+	}
+
+	if credentials.BaseURL != "" {
+		apiBackend.BaseURL = credentials.BaseURL
+	}
+	if o.httpClient != nil {
+		apiBackend.HTTPClient = o.httpClient
+	}
+	if o.timeout > 0 {
+		httpClient := *apiBackend.HTTPClient
+		httpClient.Timeout = o.timeout
+		apiBackend.HTTPClient = &httpClient
+	}
+	if o.retryPolicy != nil {
+		apiBackend.RetryPolicy = o.retryPolicy
+	}
+	if o.rateLimiter != nil {
+		apiBackend.RateLimiter = o.rateLimiter
+	}
+	if o.userAgentSuffix != "" {
+		apiBackend.UserAgent = DefaultUserAgent + " " + o.userAgentSuffix
+	}
+	if o.logger != nil {
+		apiBackend.Logger = o.logger
+	}
+
+	return apiBackend
+}
+
+// client returns the client along with the error that kept it from finding an
+// API key, if any
+func (o clientOptions) client() (*Client, error) {
+	credentials, err := o.credentialChain().Retrieve()
+
+	client := Client{
+		APIKey:              credentials.APIKey,
+		Backend:             o.backend,
+		CredentialSource:    credentials.Source,
+		Middleware:          o.middleware,
+		PreflightValidation: o.preflight,
+		regionCache:         newRegionCache(),
+	}
+	if client.Backend == nil {
+		client.Backend = o.apiBackend(credentials)
+	}
+
+	return &client, err
+}
+
+// ResolveCredentials returns the credentials NewClient would use with opts,
+// along with the error that kept it from finding an API key, if any
+func ResolveCredentials(opts ...Option) (Credentials, error) {
+	return newClientOptions(opts).credentialChain().Retrieve()
+}
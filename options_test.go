@@ -0,0 +1,112 @@
+package paperspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestConfigFile(t *testing.T, dir string, data string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, ".paperspace", "config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestWithProfileIgnoresEnv(t *testing.T) {
+	home := t.TempDir()
+	writeTestConfigFile(t, home, `{"profiles":{"research":{"apiKey":"home-key"}}}`)
+	envPath := writeTestConfigFile(t, t.TempDir(), `{"profiles":{"research":{"apiKey":"env-key"}}}`)
+
+	t.Setenv("HOME", home)
+	t.Setenv("PAPERSPACE_CONFIG_FILE", envPath)
+	t.Setenv("PAPERSPACE_PROFILE", "other")
+
+	client, err := NewValidatedClient(WithProfile("research"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.APIKey != "home-key" {
+		t.Errorf("got API key %q, want the key from ~/.paperspace/config.json", client.APIKey)
+	}
+
+	client, err = NewValidatedClient(WithEnv(), WithProfile("research"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.APIKey != "env-key" {
+		t.Errorf("got API key %q with WithEnv, want the key from PAPERSPACE_CONFIG_FILE", client.APIKey)
+	}
+}
+
+func TestWithProfileOverridesEnvAPIKey(t *testing.T) {
+	home := t.TempDir()
+	writeTestConfigFile(t, home, `{"profiles":{"default":{"apiKey":"default-key"},"research":{"apiKey":"research-key"}}}`)
+
+	t.Setenv("HOME", home)
+	t.Setenv("PAPERSPACE_CONFIG_FILE", "")
+	t.Setenv("PAPERSPACE_PROFILE", "")
+	t.Setenv("PAPERSPACE_APIKEY", "env-key")
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"profile", []Option{WithEnv(), WithProfile("research")}, "research-key"},
+		{"profile given first", []Option{WithProfile("research"), WithEnv()}, "research-key"},
+		{"missing profile", []Option{WithEnv(), WithProfile("typo")}, "env-key"},
+		{"no profile", []Option{WithEnv()}, "env-key"},
+		{"API key", []Option{WithEnv(), WithProfile("research"), WithAPIKey("key")}, "key"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if client := NewClient(test.opts...); client.APIKey != test.want {
+				t.Errorf("got API key %q, want %q", client.APIKey, test.want)
+			}
+		})
+	}
+}
+
+func TestNewValidatedClient(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		opts   []Option
+		valid  bool
+	}{
+		{"profile", `{"profiles":{"research":{"apiKey":"key"}}}`, []Option{WithProfile("research")}, true},
+		{"missing profile", `{"profiles":{"research":{"apiKey":"key"}}}`, []Option{WithProfile("typo")}, false},
+		{"malformed config file", `{"profiles":`, []Option{WithProfile("research")}, false},
+		{"no credentials", `{}`, []Option{WithBaseURL("http://localhost")}, false},
+		{"API key", `{"profiles":`, []Option{WithAPIKey("key"), WithProfile("research")}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			home := t.TempDir()
+			writeTestConfigFile(t, home, test.config)
+			t.Setenv("HOME", home)
+
+			client, err := NewValidatedClient(test.opts...)
+			if test.valid && err != nil {
+				t.Errorf("got error %v, want a client", err)
+			}
+			if !test.valid && (err == nil || client != nil) {
+				t.Errorf("got client %v and error %v, want an error", client, err)
+			}
+
+			if client := NewClient(test.opts...); client == nil {
+				t.Error("got no client from NewClient")
+			}
+		})
+	}
+}
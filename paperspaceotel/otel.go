@@ -0,0 +1,255 @@
+// Package paperspaceotel instruments paperspace-go clients with OpenTelemetry
+// traces and metrics.
+package paperspaceotel
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	paperspace "github.com/Paperspace/paperspace-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/Paperspace/paperspace-go/paperspaceotel"
+
+const (
+	ResourceKey   = attribute.Key("paperspace.resource")
+	OperationKey  = attribute.Key("paperspace.operation")
+	AttemptKey    = attribute.Key("paperspace.attempt")
+	RequestIDKey  = attribute.Key("paperspace.request_id")
+	MethodKey     = attribute.Key("http.request.method")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+	PathKey       = attribute.Key("url.path")
+	ErrorTypeKey  = attribute.Key("error.type")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+type Option func(*config)
+
+// WithTracerProvider sets the provider used for spans, defaulting to the global provider
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tracerProvider
+	}
+}
+
+// WithMeterProvider sets the provider used for metrics, defaulting to the global provider
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = meterProvider
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Instrument adds a span and metrics for every call made by client. When the
+// client uses an APIBackend, each HTTP attempt is also recorded as a child span.
+func Instrument(client *paperspace.Client, opts ...Option) {
+	client.Use(Middleware(opts...))
+
+	if apiBackend, ok := client.Backend.(*paperspace.APIBackend); ok {
+		httpClient := http.Client{}
+		if apiBackend.HTTPClient != nil {
+			httpClient = *apiBackend.HTTPClient
+		}
+		httpClient.Transport = Transport(httpClient.Transport, opts...)
+		apiBackend.HTTPClient = &httpClient
+	}
+}
+
+// Middleware records one span per logical Client call, along with the
+// paperspace.client.requests counter and paperspace.client.request.duration
+// histogram.
+func Middleware(opts ...Option) paperspace.Middleware {
+	c := newConfig(opts)
+	tracer := c.tracerProvider.Tracer(instrumentationName)
+	meter := c.meterProvider.Meter(instrumentationName)
+
+	requestCounter, _ := meter.Int64Counter("paperspace.client.requests",
+		metric.WithDescription("Number of Paperspace API calls"),
+		metric.WithUnit("{request}"),
+	)
+	requestDuration, _ := meter.Float64Histogram("paperspace.client.request.duration",
+		metric.WithDescription("Duration of Paperspace API calls, including retries"),
+		metric.WithUnit("s"),
+	)
+
+	return func(next paperspace.Backend) paperspace.Backend {
+		return paperspace.BackendFunc(func(method string, url string, params, result interface{}, requestParams paperspace.RequestParams) (*http.Response, error) {
+			ctx := requestParams.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			operation := requestParams.Operation
+			if operation == "" {
+				operation = method + " " + requestPath(url)
+			}
+
+			attrs := []attribute.KeyValue{
+				ResourceKey.String(resourceType(url)),
+				OperationKey.String(operation),
+				MethodKey.String(method),
+			}
+
+			ctx, span := tracer.Start(ctx, "paperspace."+operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			requestParams.Context = ctx
+			start := time.Now()
+			res, err := next.Request(method, url, params, result, requestParams)
+
+			var resultAttrs []attribute.KeyValue
+			if res != nil {
+				resultAttrs = append(resultAttrs, StatusCodeKey.Int(res.StatusCode))
+			}
+			if err != nil {
+				errorType := ErrorType(err)
+				resultAttrs = append(resultAttrs, ErrorTypeKey.String(errorType))
+				span.RecordError(err)
+				span.SetStatus(codes.Error, errorType)
+			}
+			span.SetAttributes(resultAttrs...)
+			attrs = append(attrs, resultAttrs...)
+
+			metricAttrs := metric.WithAttributes(attrs...)
+			requestCounter.Add(ctx, 1, metricAttrs)
+			requestDuration.Record(ctx, time.Since(start).Seconds(), metricAttrs)
+
+			return res, err
+		})
+	}
+}
+
+type transport struct {
+	base   http.RoundTripper
+	tracer trace.Tracer
+}
+
+// Transport wraps base, or http.DefaultTransport when nil, recording a span
+// for every HTTP attempt as a child of the span in the request context
+func Transport(base http.RoundTripper, opts ...Option) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	c := newConfig(opts)
+	return &transport{
+		base:   base,
+		tracer: c.tracerProvider.Tracer(instrumentationName),
+	}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attrs := []attribute.KeyValue{
+		MethodKey.String(req.Method),
+		PathKey.String(req.URL.Path),
+	}
+	if attempt := paperspace.RequestAttempt(req.Context()); attempt > 0 {
+		attrs = append(attrs, AttemptKey.Int(attempt))
+	}
+
+	ctx, span := t.tracer.Start(req.Context(), "paperspace.attempt",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, ErrorType(err))
+		return res, err
+	}
+
+	span.SetAttributes(StatusCodeKey.Int(res.StatusCode))
+	for _, header := range paperspace.RequestIDHeaders {
+		if requestID := res.Header.Get(header); requestID != "" {
+			span.SetAttributes(RequestIDKey.String(requestID))
+			break
+		}
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+
+	return res, nil
+}
+
+// ErrorType classifies an error returned by a Client method for the error.type attribute
+func ErrorType(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case paperspace.IsUnauthorized(err):
+		return "unauthorized"
+	case paperspace.IsForbidden(err):
+		return "forbidden"
+	case paperspace.IsNotFound(err):
+		return "not_found"
+	case paperspace.IsConflict(err):
+		return "conflict"
+	case paperspace.IsRateLimited(err):
+		return "rate_limited"
+	case paperspace.IsValidation(err):
+		return "validation"
+	case paperspace.IsServerError(err):
+		return "server_error"
+	case paperspace.StatusCode(err) != 0:
+		return "client_error"
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return "network"
+	}
+
+	return "unknown"
+}
+
+func requestPath(url string) string {
+	if i := strings.IndexByte(url, '?'); i >= 0 {
+		return url[:i]
+	}
+
+	return url
+}
+
+// resourceType returns the first segment of the API path, such as machines or clusters
+func resourceType(url string) string {
+	path := strings.TrimPrefix(requestPath(url), "/")
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[:i]
+	}
+
+	return path
+}
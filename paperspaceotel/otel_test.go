@@ -0,0 +1,217 @@
+package paperspaceotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	paperspace "github.com/Paperspace/paperspace-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type testInstrumentation struct {
+	client   *paperspace.Client
+	exporter *tracetest.InMemoryExporter
+	reader   *sdkmetric.ManualReader
+}
+
+func newTestInstrumentation(t *testing.T, handler http.HandlerFunc) testInstrumentation {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	retryPolicy := paperspace.NewRetryPolicy()
+	retryPolicy.MinBackoff = time.Millisecond
+
+	client := paperspace.NewClient(
+		paperspace.WithAPIKey("test"),
+		paperspace.WithBaseURL(server.URL),
+		paperspace.WithRetryPolicy(retryPolicy),
+	)
+
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	Instrument(client,
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+
+	return testInstrumentation{client: client, exporter: exporter, reader: reader}
+}
+
+func spanAttribute(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func TestInstrumentSpans(t *testing.T) {
+	var attempts int32
+	instrumentation := newTestInstrumentation(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-2")
+		w.Write([]byte(`{"id":"ps123","name":"test"}`))
+	})
+
+	if _, err := instrumentation.client.GetMachineContext(context.Background(), "ps123", paperspace.MachineGetParams{}); err != nil {
+		t.Fatalf("GetMachine: %v", err)
+	}
+
+	var parents, children []tracetest.SpanStub
+	for _, span := range instrumentation.exporter.GetSpans() {
+		if span.Name == "paperspace.attempt" {
+			children = append(children, span)
+		} else {
+			parents = append(parents, span)
+		}
+	}
+
+	if len(parents) != 1 {
+		t.Fatalf("got %d call spans, want 1", len(parents))
+	}
+	parent := parents[0]
+	if parent.Name != "paperspace.GetMachine" {
+		t.Errorf("got span name %q, want %q", parent.Name, "paperspace.GetMachine")
+	}
+	if value, _ := spanAttribute(parent, OperationKey); value.AsString() != "GetMachine" {
+		t.Errorf("got %s %q, want %q", OperationKey, value.AsString(), "GetMachine")
+	}
+	if value, _ := spanAttribute(parent, StatusCodeKey); value.AsInt64() != http.StatusOK {
+		t.Errorf("got %s %d, want %d", StatusCodeKey, value.AsInt64(), http.StatusOK)
+	}
+	if _, ok := spanAttribute(parent, ErrorTypeKey); ok {
+		t.Errorf("got %s on a successful call", ErrorTypeKey)
+	}
+
+	if len(children) != 2 {
+		t.Fatalf("got %d attempt spans, want 2", len(children))
+	}
+	for i, child := range children {
+		if child.Parent.SpanID() != parent.SpanContext.SpanID() {
+			t.Errorf("attempt %d is not a child of the call span", i+1)
+		}
+		if value, _ := spanAttribute(child, AttemptKey); value.AsInt64() != int64(i+1) {
+			t.Errorf("got %s %d, want %d", AttemptKey, value.AsInt64(), i+1)
+		}
+	}
+	if value, _ := spanAttribute(children[0], StatusCodeKey); value.AsInt64() != http.StatusServiceUnavailable {
+		t.Errorf("got first attempt status %d, want %d", value.AsInt64(), http.StatusServiceUnavailable)
+	}
+	if children[0].Status.Code != codes.Error {
+		t.Errorf("got first attempt span status %v, want %v", children[0].Status.Code, codes.Error)
+	}
+	if value, _ := spanAttribute(children[1], RequestIDKey); value.AsString() != "req-2" {
+		t.Errorf("got %s %q, want %q", RequestIDKey, value.AsString(), "req-2")
+	}
+}
+
+func TestInstrumentError(t *testing.T) {
+	instrumentation := newTestInstrumentation(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"name":"NotFound","message":"machine not found"}}`))
+	})
+
+	_, err := instrumentation.client.GetMachineContext(context.Background(), "ps404", paperspace.MachineGetParams{})
+	if !paperspace.IsNotFound(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+
+	spans := instrumentation.exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want a call span and one attempt span", len(spans))
+	}
+
+	var parent tracetest.SpanStub
+	for _, span := range spans {
+		if span.Name != "paperspace.attempt" {
+			parent = span
+		}
+	}
+	if parent.Status.Code != codes.Error {
+		t.Errorf("got span status %v, want %v", parent.Status.Code, codes.Error)
+	}
+	if value, _ := spanAttribute(parent, ErrorTypeKey); value.AsString() != "not_found" {
+		t.Errorf("got %s %q, want %q", ErrorTypeKey, value.AsString(), "not_found")
+	}
+	if value, _ := spanAttribute(parent, StatusCodeKey); value.AsInt64() != http.StatusNotFound {
+		t.Errorf("got %s %d, want %d", StatusCodeKey, value.AsInt64(), http.StatusNotFound)
+	}
+}
+
+func TestInstrumentMetrics(t *testing.T) {
+	instrumentation := newTestInstrumentation(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/machines/getMachinePublic" {
+			w.Write([]byte(`{"id":"ps123"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ctx := context.Background()
+	instrumentation.client.GetMachineContext(ctx, "ps123", paperspace.MachineGetParams{})
+	instrumentation.client.GetMachineContext(ctx, "ps123", paperspace.MachineGetParams{})
+	instrumentation.client.GetTemplatesContext(ctx, paperspace.TemplateListParams{})
+
+	resourceMetrics := metricdata.ResourceMetrics{}
+	if err := instrumentation.reader.Collect(ctx, &resourceMetrics); err != nil {
+		t.Fatalf("collecting metrics: %v", err)
+	}
+
+	var requests metricdata.Sum[int64]
+	var duration metricdata.Histogram[float64]
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			switch m.Name {
+			case "paperspace.client.requests":
+				requests, _ = m.Data.(metricdata.Sum[int64])
+			case "paperspace.client.request.duration":
+				duration, _ = m.Data.(metricdata.Histogram[float64])
+			}
+		}
+	}
+
+	counts := map[string]int64{}
+	for _, dataPoint := range requests.DataPoints {
+		operation, _ := dataPoint.Attributes.Value(OperationKey)
+		statusCode, _ := dataPoint.Attributes.Value(StatusCodeKey)
+		errorType, _ := dataPoint.Attributes.Value(ErrorTypeKey)
+		counts[operation.AsString()+" "+statusCode.Emit()+" "+errorType.AsString()] += dataPoint.Value
+	}
+
+	want := map[string]int64{
+		"GetMachine 200 ":            2,
+		"GetTemplates 404 not_found": 1,
+	}
+	for key, count := range want {
+		if counts[key] != count {
+			t.Errorf("got %d requests for %q, want %d, all counts %v", counts[key], key, count, counts)
+		}
+	}
+	if len(counts) != len(want) {
+		t.Errorf("got request counts %v, want %v", counts, want)
+	}
+
+	var durationCount uint64
+	for _, dataPoint := range duration.DataPoints {
+		durationCount += dataPoint.Count
+	}
+	if durationCount != 3 {
+		t.Errorf("got %d durations recorded, want 3", durationCount)
+	}
+}
@@ -0,0 +1,117 @@
+package paperspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PublicIP is a static public IP address that stays with the team until it is
+// released, and can be moved between machines
+type PublicIP struct {
+	IP        string    `json:"ip"`
+	Region    string    `json:"region"`
+	MachineID string    `json:"machineId"`
+	TeamID    string    `json:"teamId"`
+	DtCreated time.Time `json:"dtCreated"`
+}
+
+type PublicIPAllocateParams struct {
+	RequestParams
+
+	Region string `json:"region"`
+}
+
+type PublicIPAssignParams struct {
+	RequestParams
+
+	MachineID string `json:"machineId"`
+}
+
+type PublicIPListParams struct {
+	RequestParams
+
+	Filter    Filter `json:"filter,omitempty"`
+	Region    string `json:"region,omitempty"`
+	MachineID string `json:"machineId,omitempty"`
+}
+
+type PublicIPReleaseParams struct {
+	RequestParams
+}
+
+type PublicIPUnassignParams struct {
+	RequestParams
+}
+
+func (c Client) AllocatePublicIP(params PublicIPAllocateParams) (PublicIP, error) {
+	return c.AllocatePublicIPContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) AllocatePublicIPContext(ctx context.Context, params PublicIPAllocateParams) (PublicIP, error) {
+	params.Context = ctx
+
+	publicIP := PublicIP{}
+
+	url := "/publicIps"
+	_, err := c.Request("POST", url, params, &publicIP, params.RequestParams.withOperation("AllocatePublicIP"))
+
+	return publicIP, err
+}
+
+// AssignPublicIP attaches the address to a machine, moving it from any machine it was assigned to
+func (c Client) AssignPublicIP(ip string, params PublicIPAssignParams) (PublicIP, error) {
+	return c.AssignPublicIPContext(params.RequestParams.contextOrBackground(), ip, params)
+}
+
+func (c Client) AssignPublicIPContext(ctx context.Context, ip string, params PublicIPAssignParams) (PublicIP, error) {
+	params.Context = ctx
+
+	publicIP := PublicIP{}
+
+	url := fmt.Sprintf("/publicIps/%s/assign", ip)
+	_, err := c.Request("POST", url, params, &publicIP, params.RequestParams.withOperation("AssignPublicIP"))
+
+	return publicIP, err
+}
+
+func (c Client) UnassignPublicIP(ip string, params PublicIPUnassignParams) error {
+	return c.UnassignPublicIPContext(params.RequestParams.contextOrBackground(), ip, params)
+}
+
+func (c Client) UnassignPublicIPContext(ctx context.Context, ip string, params PublicIPUnassignParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/publicIps/%s/unassign", ip)
+	_, err := c.Request("POST", url, nil, nil, params.RequestParams.withOperation("UnassignPublicIP"))
+
+	return err
+}
+
+func (c Client) ReleasePublicIP(ip string, params PublicIPReleaseParams) error {
+	return c.ReleasePublicIPContext(params.RequestParams.contextOrBackground(), ip, params)
+}
+
+func (c Client) ReleasePublicIPContext(ctx context.Context, ip string, params PublicIPReleaseParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/publicIps/%s", ip)
+	_, err := c.Request("DELETE", url, nil, nil, params.RequestParams.withOperation("ReleasePublicIP"))
+
+	return err
+}
+
+func (c Client) GetPublicIPs(params PublicIPListParams) ([]PublicIP, error) {
+	return c.GetPublicIPsContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetPublicIPsContext(ctx context.Context, params PublicIPListParams) ([]PublicIP, error) {
+	params.Context = ctx
+
+	var publicIPs []PublicIP
+
+	url := "/publicIps"
+	_, err := c.Request("GET", url, params, &publicIPs, params.RequestParams.withOperation("GetPublicIPs"))
+
+	return publicIPs, err
+}
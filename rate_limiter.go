@@ -0,0 +1,178 @@
+package paperspace
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiter shared by every request made through
+// an APIBackend. It slows down when the API responds with 429 or Retry-After
+// and recovers gradually as requests succeed.
+type RateLimiter struct {
+	// QPS is the steady state number of requests per second
+	QPS float64
+	// Burst is the number of requests that can be made at once
+	Burst int
+	// PerEndpoint gives every operation its own bucket instead of sharing one
+	PerEndpoint bool
+	// MinQPS is the lowest rate the limiter slows down to after being rate limited
+	MinQPS float64
+	// BackoffFactor multiplies the current rate each time a 429 or Retry-After is observed
+	BackoffFactor float64
+	// RecoveryRate is added to the current rate, as a fraction of QPS, after each
+	// response that isn't a server error
+	RecoveryRate float64
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	rate        float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func NewRateLimiter(qps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		QPS:           qps,
+		Burst:         burst,
+		MinQPS:        qps / 10,
+		BackoffFactor: 0.5,
+		RecoveryRate:  0.05,
+	}
+}
+
+// Wait blocks until a request for endpoint may be made or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context, endpoint string) error {
+	if l.QPS <= 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	bucket := l.bucket(endpoint)
+	now := time.Now()
+	l.refill(bucket, now)
+
+	// reserve a token now so concurrent callers queue behind each other
+	bucket.tokens--
+	var wait time.Duration
+	if bucket.tokens < 0 {
+		wait = time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+	}
+	if bucket.pausedUntil.After(now) {
+		wait += bucket.pausedUntil.Sub(now)
+	}
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, wait); err != nil {
+		l.mu.Lock()
+		bucket.tokens++
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Observe adapts the rate of endpoint to the response of a request
+func (l *RateLimiter) Observe(endpoint string, res *http.Response) {
+	if l.QPS <= 0 || res == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket := l.bucket(endpoint)
+	now := time.Now()
+	l.refill(bucket, now)
+
+	// any response asking to retry later, such as a 503 during maintenance,
+	// slows the limiter down the same way as a 429
+	retryAfter, hasRetryAfter := parseRetryAfter(res.Header.Get("Retry-After"), now)
+	if res.StatusCode != http.StatusTooManyRequests && !hasRetryAfter {
+		if res.StatusCode < http.StatusInternalServerError {
+			bucket.rate = math.Min(l.QPS, bucket.rate+l.QPS*l.RecoveryRate)
+		}
+		return
+	}
+
+	bucket.rate = math.Max(l.minQPS(), bucket.rate*l.BackoffFactor)
+	if bucket.tokens > 0 {
+		bucket.tokens = 0
+	}
+	if hasRetryAfter {
+		if pausedUntil := now.Add(retryAfter); pausedUntil.After(bucket.pausedUntil) {
+			bucket.pausedUntil = pausedUntil
+		}
+	}
+}
+
+// Rate returns the current rate of endpoint in requests per second
+func (l *RateLimiter) Rate(endpoint string) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.bucket(endpoint).rate
+}
+
+func (l *RateLimiter) bucket(endpoint string) *tokenBucket {
+	if !l.PerEndpoint {
+		endpoint = ""
+	}
+
+	if l.buckets == nil {
+		l.buckets = make(map[string]*tokenBucket)
+	}
+
+	bucket, ok := l.buckets[endpoint]
+	if !ok {
+		bucket = &tokenBucket{
+			rate:   l.QPS,
+			tokens: float64(l.burst()),
+			last:   time.Now(),
+		}
+		l.buckets[endpoint] = bucket
+	}
+
+	return bucket
+}
+
+func (l *RateLimiter) refill(bucket *tokenBucket, now time.Time) {
+	elapsed := now.Sub(bucket.last).Seconds()
+	if elapsed > 0 {
+		bucket.tokens = math.Min(float64(l.burst()), bucket.tokens+elapsed*bucket.rate)
+		bucket.last = now
+	}
+}
+
+func (l *RateLimiter) burst() int {
+	if l.Burst < 1 {
+		return 1
+	}
+
+	return l.Burst
+}
+
+func (l *RateLimiter) minQPS() float64 {
+	if l.MinQPS > 0 {
+		return math.Min(l.MinQPS, l.QPS)
+	}
+
+	return l.QPS / 10
+}
+
+// rateLimiterEndpoint names the bucket of a request, grouping requests by
+// operation so that IDs in the path don't create a bucket per resource
+func rateLimiterEndpoint(method string, url string, requestParams RequestParams) string {
+	if requestParams.Operation != "" {
+		return requestParams.Operation
+	}
+
+	return method + " " + requestPath(url)
+}
@@ -0,0 +1,47 @@
+package paperspace
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterObserve(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		retryAfter string
+		rate       float64
+		paused     bool
+	}{
+		{"success", http.StatusOK, "", 8.5, false},
+		{"429", http.StatusTooManyRequests, "", 4, false},
+		{"429 with Retry-After", http.StatusTooManyRequests, "2", 4, true},
+		{"503 with Retry-After", http.StatusServiceUnavailable, "2", 4, true},
+		{"success with Retry-After", http.StatusAccepted, "2", 4, true},
+		{"503", http.StatusServiceUnavailable, "", 8, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rateLimiter := NewRateLimiter(10, 1)
+			// start below QPS so recovery is visible, backing off halves it
+			rateLimiter.bucket("").rate = 8
+
+			header := http.Header{}
+			if test.retryAfter != "" {
+				header.Set("Retry-After", test.retryAfter)
+			}
+			rateLimiter.Observe("", &http.Response{StatusCode: test.statusCode, Header: header})
+
+			if got := rateLimiter.Rate(""); got != test.rate {
+				t.Errorf("got rate %v, want %v", got, test.rate)
+			}
+
+			paused := rateLimiter.bucket("").pausedUntil.After(time.Now())
+			if paused != test.paused {
+				t.Errorf("got paused %v, want %v", paused, test.paused)
+			}
+		})
+	}
+}
@@ -1,7 +1,163 @@
 package paperspace
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RegionMap is used to resolve regions when the regions API can't be reached
 var RegionMap = map[string]int{
 	"East Coast (NY2)": 1,
 	"West Coast (CA1)": 2,
 	"Europe (AMS1)":    3,
 }
+
+var DefaultRegionCacheTTL = time.Hour
+
+type Region struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Code         string   `json:"code"`
+	IsAvailable  bool     `json:"isAvailable"`
+	MachineTypes []string `json:"machineTypes"`
+}
+
+// Matches reports whether name is the region's ID, name or code, ignoring case
+func (r Region) Matches(name string) bool {
+	name = strings.TrimSpace(name)
+	return strings.EqualFold(r.Name, name) || strings.EqualFold(r.Code, name) || strconv.Itoa(r.ID) == name
+}
+
+func (r Region) SupportsMachineType(machineType string) bool {
+	for _, supportedMachineType := range r.MachineTypes {
+		if supportedMachineType == machineType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type RegionListParams struct {
+	RequestParams
+}
+
+// regionCache holds the regions listed by a Client so resolving a region
+// doesn't list them on every call
+type regionCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	regions   []Region
+	fetchedAt time.Time
+}
+
+func newRegionCache() *regionCache {
+	return &regionCache{ttl: DefaultRegionCacheTTL}
+}
+
+func (c Client) GetRegions(params RegionListParams) ([]Region, error) {
+	return c.GetRegionsContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetRegionsContext(ctx context.Context, params RegionListParams) ([]Region, error) {
+	params.Context = ctx
+
+	var regions []Region
+
+	url := "/regions"
+	_, err := c.Request("GET", url, nil, &regions, params.RequestParams.withOperation("GetRegions"))
+
+	return regions, err
+}
+
+// ResolveRegion finds a region by ID, name such as "East Coast (NY2)" or code
+// such as NY2. Regions are cached by clients created with NewClient, and
+// RegionMap is used when the regions API can't be reached.
+func (c Client) ResolveRegion(name string, params RegionListParams) (Region, error) {
+	return c.ResolveRegionContext(params.RequestParams.contextOrBackground(), name, params)
+}
+
+func (c Client) ResolveRegionContext(ctx context.Context, name string, params RegionListParams) (Region, error) {
+	params.Context = ctx
+
+	regions, err := c.cachedRegions(ctx, params)
+	if err != nil {
+		var apiError *APIError
+		if errors.As(err, &apiError) || ctx.Err() != nil {
+			return Region{}, err
+		}
+
+		return fallbackRegion(name, err)
+	}
+
+	for _, region := range regions {
+		if region.Matches(name) {
+			return region, nil
+		}
+	}
+
+	return Region{}, NotFoundError{Resource: "region", Key: name}
+}
+
+func (c Client) cachedRegions(ctx context.Context, params RegionListParams) ([]Region, error) {
+	if c.regionCache == nil {
+		return c.GetRegionsContext(ctx, params)
+	}
+
+	if regions, ok := c.regionCache.get(); ok {
+		return regions, nil
+	}
+
+	// the lock isn't held while listing, so a slow request doesn't hold up
+	// callers whose own context would end sooner
+	regions, err := c.GetRegionsContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	c.regionCache.set(regions, time.Now())
+
+	return regions, nil
+}
+
+func (c *regionCache) get() ([]Region, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.regions == nil || time.Since(c.fetchedAt) >= c.ttl {
+		return nil, false
+	}
+
+	return c.regions, true
+}
+
+func (c *regionCache) set(regions []Region, fetchedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if fetchedAt.After(c.fetchedAt) {
+		c.regions = regions
+		c.fetchedAt = fetchedAt
+	}
+}
+
+// fallbackRegion looks name up in RegionMap, returning listErr when it isn't there
+func fallbackRegion(name string, listErr error) (Region, error) {
+	for regionName, regionID := range RegionMap {
+		region := Region{ID: regionID, Name: regionName, IsAvailable: true}
+		if start, end := strings.LastIndex(regionName, "("), strings.LastIndex(regionName, ")"); start >= 0 && end > start {
+			region.Code = regionName[start+1 : end]
+		}
+
+		if region.Matches(name) {
+			return region, nil
+		}
+	}
+
+	return Region{}, fmt.Errorf("no region found for %s: %w", name, listErr)
+}
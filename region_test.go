@@ -0,0 +1,137 @@
+package paperspace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testRegions = `[
+	{"id":1,"name":"East Coast (NY2)","code":"NY2","isAvailable":true},
+	{"id":2,"name":"West Coast (CA1)","code":"CA1","isAvailable":true},
+	{"id":4,"name":"Asia (SG1)","code":"SG1","isAvailable":false}
+]`
+
+func TestResolveRegion(t *testing.T) {
+	client, polls := newTestClient(t, testResponse{http.StatusOK, testRegions})
+
+	tests := []struct {
+		name string
+		id   int
+	}{
+		{"East Coast (NY2)", 1},
+		{"west coast (ca1)", 2},
+		{"sg1", 4},
+		{"2", 2},
+		{" NY2 ", 1},
+	}
+	for _, test := range tests {
+		region, err := client.ResolveRegion(test.name, RegionListParams{})
+		if err != nil {
+			t.Errorf("resolving %q: %v", test.name, err)
+			continue
+		}
+		if region.ID != test.id {
+			t.Errorf("got region %d for %q, want %d", region.ID, test.name, test.id)
+		}
+	}
+
+	if _, err := client.ResolveRegion("AMS9", RegionListParams{}); !IsNotFound(err) {
+		t.Errorf("got error %v for an unknown region, want not found", err)
+	}
+	if got := atomic.LoadInt32(polls); got != 1 {
+		t.Errorf("got %d region requests, want 1 while cached", got)
+	}
+}
+
+func TestResolveRegionCacheTTL(t *testing.T) {
+	client, polls := newTestClient(t, testResponse{http.StatusOK, testRegions})
+	client.regionCache.ttl = 50 * time.Millisecond
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.ResolveRegion("NY2", RegionListParams{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := client.ResolveRegion("NY2", RegionListParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(polls); got != 2 {
+		t.Errorf("got %d region requests, want 2 after the cache expired", got)
+	}
+}
+
+func TestResolveRegionAPIError(t *testing.T) {
+	client, polls := newTestClient(t,
+		testResponse{http.StatusInternalServerError, `{"error":{"message":"internal"}}`},
+		testResponse{http.StatusOK, testRegions},
+	)
+
+	if _, err := client.ResolveRegion("NY2", RegionListParams{}); !IsServerError(err) {
+		t.Errorf("got error %v, want the API error instead of a RegionMap fallback", err)
+	}
+
+	// failures aren't cached
+	region, err := client.ResolveRegion("NY2", RegionListParams{})
+	if err != nil || region.ID != 1 {
+		t.Errorf("got region %v and error %v, want NY2 once the API recovers", region, err)
+	}
+	if got := atomic.LoadInt32(polls); got != 2 {
+		t.Errorf("got %d region requests, want 2", got)
+	}
+}
+
+func TestResolveRegionOffline(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithRetryPolicy(&RetryPolicy{}))
+
+	for name, id := range map[string]int{"NY2": 1, "West Coast (CA1)": 2, "3": 3} {
+		region, err := client.ResolveRegion(name, RegionListParams{})
+		if err != nil {
+			t.Errorf("resolving %q offline: %v", name, err)
+			continue
+		}
+		if region.ID != id {
+			t.Errorf("got region %d for %q, want %d from RegionMap", region.ID, name, id)
+		}
+	}
+
+	_, err := client.ResolveRegion("SG1", RegionListParams{})
+	if err == nil || IsNotFound(err) {
+		t.Errorf("got error %v for a region missing from RegionMap, want the network error", err)
+	}
+}
+
+func TestResolveRegionDoesNotBlockOnSlowFetch(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(testRegions))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(WithAPIKey("test"), WithBaseURL(server.URL), WithRetryPolicy(&RetryPolicy{}))
+
+	go client.ResolveRegion("NY2", RegionListParams{})
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.ResolveRegionContext(ctx, "NY2", RegionListParams{})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("resolving took %s, want it to end with its own context", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want it to match context.DeadlineExceeded", err)
+	}
+}
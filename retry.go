@@ -0,0 +1,217 @@
+package paperspace
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// UnprocessedStatusCodes are the retryable statuses that mean the API rejected
+// a request without acting on it, so any method can be retried after them
+var UnprocessedStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusServiceUnavailable,
+}
+
+// IdempotentMethods are the methods retried after any retryable status or
+// network error
+var IdempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodDelete,
+}
+
+// RetryPolicy controls how APIBackend retries failed requests
+type RetryPolicy struct {
+	// MaxRetries is the number of retries made after the initial attempt
+	MaxRetries int
+	// RetryableStatusCodes are the HTTP statuses that trigger a retry
+	RetryableStatusCodes []int
+	// RetryNetworkErrors retries requests that failed before a response was read,
+	// such as connection resets, refused connections and timeouts
+	RetryNetworkErrors bool
+	// MinBackoff is the wait before the first retry, doubled on each attempt
+	MinBackoff time.Duration
+	// MaxBackoff caps a single wait, including waits requested by Retry-After
+	MaxBackoff time.Duration
+	// Jitter is the fraction of each backoff, between 0 and 1, that is randomized
+	Jitter float64
+	// MaxElapsedTime stops retrying once the next wait would exceed it, zero means no limit
+	MaxElapsedTime time.Duration
+	// RetryNonIdempotent retries methods other than IdempotentMethods, such as
+	// POST, the same way as idempotent ones
+	RetryNonIdempotent bool
+}
+
+// NewRetryPolicy returns the default policy, which retries GET and DELETE
+// requests after a retryable status or network error. Other methods, such as
+// the POST that creates a machine, may already have been acted on when the
+// response is a 502 or 504 or the connection times out or is reset, so they
+// are only retried when the request was refused or answered with one of
+// UnprocessedStatusCodes. Set RetryNonIdempotent to retry them anyway, at the
+// risk of creating a resource twice.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:           3,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+		RetryNetworkErrors:   true,
+		MinBackoff:           500 * time.Millisecond,
+		MaxBackoff:           30 * time.Second,
+		Jitter:               0.2,
+		MaxElapsedTime:       2 * time.Minute,
+	}
+}
+
+func (p *RetryPolicy) isRetryableStatus(statusCode int) bool {
+	return containsStatusCode(p.RetryableStatusCodes, statusCode)
+}
+
+func (p *RetryPolicy) shouldRetry(method string, res *http.Response, err error) bool {
+	idempotent := p.RetryNonIdempotent || isIdempotentMethod(method)
+
+	if res != nil {
+		if !p.isRetryableStatus(res.StatusCode) {
+			return false
+		}
+		return idempotent || containsStatusCode(UnprocessedStatusCodes, res.StatusCode)
+	}
+
+	if !p.RetryNetworkErrors {
+		return false
+	}
+	if idempotent {
+		return isNetworkError(err)
+	}
+
+	return isDialError(err)
+}
+
+func isIdempotentMethod(method string) bool {
+	for _, idempotentMethod := range IdempotentMethods {
+		if strings.EqualFold(method, idempotentMethod) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsStatusCode(statusCodes []int, statusCode int) bool {
+	for _, code := range statusCodes {
+		if statusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the wait before the given retry, starting at 0 for the first retry
+func (p *RetryPolicy) backoff(retry int, res *http.Response) time.Duration {
+	if res != nil {
+		if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			return p.capBackoff(retryAfter)
+		}
+	}
+
+	backoff := float64(p.MinBackoff) * math.Pow(2, float64(retry))
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		backoff = backoff * (1 - jitter + 2*jitter*rand.Float64())
+	}
+
+	return p.capBackoff(time.Duration(backoff))
+}
+
+func (p *RetryPolicy) capBackoff(backoff time.Duration) time.Duration {
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	if backoff < 0 {
+		return 0
+	}
+
+	return backoff
+}
+
+// parseRetryAfter accepts both the delay-seconds and HTTP-date forms of Retry-After
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isDialError reports whether the request failed while connecting, such as
+// when the connection is refused, so it was never sent
+func isDialError(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
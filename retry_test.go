@@ -0,0 +1,66 @@
+package paperspace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyNonIdempotent(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		statusCode         int
+		retryNonIdempotent bool
+		attempts           int32
+	}{
+		{"GET after 504", "GET", http.StatusGatewayTimeout, false, 4},
+		{"DELETE after 502", "DELETE", http.StatusBadGateway, false, 4},
+		{"POST after 504", "POST", http.StatusGatewayTimeout, false, 1},
+		{"POST after 502", "POST", http.StatusBadGateway, false, 1},
+		{"POST after 503", "POST", http.StatusServiceUnavailable, false, 4},
+		{"POST after 429", "POST", http.StatusTooManyRequests, false, 4},
+		{"POST after 504 with RetryNonIdempotent", "POST", http.StatusGatewayTimeout, true, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apiBackend, attempts := newTestAPIBackend(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statusCode)
+			})
+			retryPolicy := NewRetryPolicy()
+			retryPolicy.MinBackoff = time.Millisecond
+			retryPolicy.RetryNonIdempotent = test.retryNonIdempotent
+			apiBackend.RetryPolicy = retryPolicy
+
+			_, err := apiBackend.Request(test.method, "/machines/createSingleMachinePublic", nil, nil, RequestParams{})
+
+			if StatusCode(err) != test.statusCode {
+				t.Errorf("got error %v, want status %d", err, test.statusCode)
+			}
+			if got := atomic.LoadInt32(attempts); got != test.attempts {
+				t.Errorf("got %d attempts, want %d", got, test.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyRefusedConnection(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	apiBackend := newAPIBackend()
+	apiBackend.BaseURL = server.URL
+	apiBackend.RetryPolicy = NewRetryPolicy()
+	apiBackend.RetryPolicy.MinBackoff = time.Millisecond
+
+	_, err := apiBackend.Request("POST", "/machines/createSingleMachinePublic", nil, nil, RequestParams{})
+	if err == nil {
+		t.Fatal("got no error from a closed server")
+	}
+	if !apiBackend.RetryPolicy.shouldRetry("POST", nil, err) {
+		t.Errorf("got no retry for %v, want a refused POST to be retried", err)
+	}
+}
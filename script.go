@@ -0,0 +1,208 @@
+package paperspace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+type Script struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	OwnerType   string    `json:"ownerType"`
+	OwnerID     string    `json:"ownerId"`
+	TeamID      string    `json:"teamId"`
+	IsEnabled   bool      `json:"isEnabled"`
+	RunOnce     bool      `json:"runOnce"`
+	DtCreated   time.Time `json:"dtCreated"`
+}
+
+type ScriptCreateParams struct {
+	RequestParams
+
+	Name        string `json:"scriptName"`
+	Description string `json:"scriptDescription,omitempty"`
+	Text        string `json:"scriptText,omitempty"`
+	// Reader, when set, is read into Text before the script is created
+	Reader    io.Reader `json:"-"`
+	IsEnabled *bool     `json:"isEnabled,omitempty"`
+	RunOnce   *bool     `json:"runOnce,omitempty"`
+	MachineID string    `json:"machineId,omitempty"`
+}
+
+type ScriptDeleteParams struct {
+	RequestParams
+}
+
+type ScriptGetParams struct {
+	RequestParams
+}
+
+type ScriptListParams struct {
+	RequestParams
+
+	Filter Filter `json:"filter,omitempty"`
+}
+
+type ScriptUpdateParams struct {
+	RequestParams
+
+	ID          string `json:"scriptId"`
+	Name        string `json:"scriptName,omitempty"`
+	Description string `json:"scriptDescription,omitempty"`
+	Text        string `json:"scriptText,omitempty"`
+	// Reader, when set, is read into Text before the script is updated
+	Reader    io.Reader `json:"-"`
+	IsEnabled *bool     `json:"isEnabled,omitempty"`
+	RunOnce   *bool     `json:"runOnce,omitempty"`
+}
+
+// scriptText decodes the body of getScriptText, which is either the text as a
+// JSON string or an object holding it
+type scriptText string
+
+func (t *scriptText) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*t = scriptText(text)
+		return nil
+	}
+
+	var object struct {
+		ScriptText *string `json:"scriptText"`
+		Text       *string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("decoding script text: %w", err)
+	}
+
+	switch {
+	case object.ScriptText != nil:
+		*t = scriptText(*object.ScriptText)
+	case object.Text != nil:
+		*t = scriptText(*object.Text)
+	default:
+		return errors.New("decoding script text: no scriptText or text field")
+	}
+
+	return nil
+}
+
+func readScriptText(reader io.Reader, text string) (string, error) {
+	if reader == nil {
+		return text, nil
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("reading script text: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func (c Client) CreateScript(params ScriptCreateParams) (Script, error) {
+	return c.CreateScriptContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) CreateScriptContext(ctx context.Context, params ScriptCreateParams) (Script, error) {
+	params.Context = ctx
+
+	script := Script{}
+
+	text, err := readScriptText(params.Reader, params.Text)
+	if err != nil {
+		return script, err
+	}
+	params.Text = text
+
+	url := "/scripts/createScript"
+	_, err = c.Request("POST", url, params, &script, params.RequestParams.withOperation("CreateScript"))
+
+	return script, err
+}
+
+func (c Client) GetScript(id string, params ScriptGetParams) (Script, error) {
+	return c.GetScriptContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetScriptContext(ctx context.Context, id string, params ScriptGetParams) (Script, error) {
+	params.Context = ctx
+
+	script := Script{}
+
+	url := fmt.Sprintf("/scripts/getScript?scriptId=%s", id)
+	_, err := c.Request("GET", url, nil, &script, params.RequestParams.withOperation("GetScript"))
+
+	return script, err
+}
+
+// GetScriptText returns the body of the script
+func (c Client) GetScriptText(id string, params ScriptGetParams) (string, error) {
+	return c.GetScriptTextContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetScriptTextContext(ctx context.Context, id string, params ScriptGetParams) (string, error) {
+	params.Context = ctx
+
+	var text scriptText
+
+	url := fmt.Sprintf("/scripts/getScriptText?scriptId=%s", id)
+	_, err := c.Request("GET", url, nil, &text, params.RequestParams.withOperation("GetScriptText"))
+
+	return string(text), err
+}
+
+func (c Client) GetScripts(params ScriptListParams) ([]Script, error) {
+	return c.GetScriptsContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetScriptsContext(ctx context.Context, params ScriptListParams) ([]Script, error) {
+	params.Context = ctx
+
+	var scripts []Script
+
+	url := "/scripts/getScripts"
+	_, err := c.Request("GET", url, params, &scripts, params.RequestParams.withOperation("GetScripts"))
+
+	return scripts, err
+}
+
+func (c Client) UpdateScript(params ScriptUpdateParams) (Script, error) {
+	return c.UpdateScriptContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) UpdateScriptContext(ctx context.Context, params ScriptUpdateParams) (Script, error) {
+	params.Context = ctx
+
+	script := Script{}
+
+	text, err := readScriptText(params.Reader, params.Text)
+	if err != nil {
+		return script, err
+	}
+	params.Text = text
+
+	url := "/scripts/updateScript"
+	_, err = c.Request("POST", url, params, &script, params.RequestParams.withOperation("UpdateScript"))
+
+	return script, err
+}
+
+func (c Client) DeleteScript(id string, params ScriptDeleteParams) error {
+	return c.DeleteScriptContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) DeleteScriptContext(ctx context.Context, id string, params ScriptDeleteParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/scripts/%s/destroy", id)
+	_, err := c.Request("POST", url, nil, nil, params.RequestParams.withOperation("DeleteScript"))
+
+	return err
+}
@@ -0,0 +1,58 @@
+package paperspace
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetScriptText(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"string", `"#!/bin/sh\necho hello\n"`, "#!/bin/sh\necho hello\n"},
+		{"scriptText object", `{"scriptText":"echo hello"}`, "echo hello"},
+		{"text object", `{"id":"sc123","text":"echo hello"}`, "echo hello"},
+		{"empty text", `{"scriptText":""}`, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, _ := newTestClient(t, testResponse{http.StatusOK, test.body})
+
+			text, err := client.GetScriptText("sc123", ScriptGetParams{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if text != test.want {
+				t.Errorf("got %q, want %q", text, test.want)
+			}
+		})
+	}
+}
+
+func TestGetScriptTextUnknownBody(t *testing.T) {
+	client, _ := newTestClient(t, testResponse{http.StatusOK, `{"id":"sc123"}`})
+
+	if _, err := client.GetScriptText("sc123", ScriptGetParams{}); err == nil {
+		t.Error("got no error for a body without the script text")
+	}
+}
+
+// A Backend that decodes JSON like APIBackend does works with GetScriptText
+func TestGetScriptTextCustomBackend(t *testing.T) {
+	backend := BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, json.Unmarshal([]byte(`"echo hello"`), result)
+	})
+	client := NewClient(WithAPIKey("test"), WithBackend(backend))
+
+	text, err := client.GetScriptText("sc123", ScriptGetParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "echo hello" {
+		t.Errorf("got %q, want %q", text, "echo hello")
+	}
+}
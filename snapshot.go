@@ -0,0 +1,117 @@
+package paperspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type Snapshot struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	MachineID      string    `json:"machineId"`
+	TeamID         string    `json:"teamId"`
+	State          string    `json:"state"`
+	Size           int64     `json:"size,string"`
+	IsAutoSnapshot bool      `json:"isAutoSnapshot"`
+	IsRestorePoint bool      `json:"isRestorePoint"`
+	DtCreated      time.Time `json:"dtCreated"`
+	DtDeleted      time.Time `json:"dtDeleted"`
+}
+
+type SnapshotCreateParams struct {
+	RequestParams
+
+	Name               string `json:"name,omitempty"`
+	MarkAsRestorePoint *bool  `json:"markAsRestorePoint,omitempty"`
+}
+
+type SnapshotDeleteParams struct {
+	RequestParams
+}
+
+type SnapshotListParams struct {
+	RequestParams
+
+	Filter Filter `json:"filter,omitempty"`
+}
+
+type SnapshotRestoreParams struct {
+	RequestParams
+}
+
+type SnapshotRestorePointParams struct {
+	RequestParams
+}
+
+func (c Client) CreateMachineSnapshot(machineID string, params SnapshotCreateParams) (Snapshot, error) {
+	return c.CreateMachineSnapshotContext(params.RequestParams.contextOrBackground(), machineID, params)
+}
+
+func (c Client) CreateMachineSnapshotContext(ctx context.Context, machineID string, params SnapshotCreateParams) (Snapshot, error) {
+	params.Context = ctx
+
+	snapshot := Snapshot{}
+
+	url := fmt.Sprintf("/machines/%s/snapshots", machineID)
+	_, err := c.Request("POST", url, params, &snapshot, params.RequestParams.withOperation("CreateMachineSnapshot"))
+
+	return snapshot, err
+}
+
+func (c Client) GetMachineSnapshots(machineID string, params SnapshotListParams) ([]Snapshot, error) {
+	return c.GetMachineSnapshotsContext(params.RequestParams.contextOrBackground(), machineID, params)
+}
+
+func (c Client) GetMachineSnapshotsContext(ctx context.Context, machineID string, params SnapshotListParams) ([]Snapshot, error) {
+	params.Context = ctx
+
+	var snapshots []Snapshot
+
+	url := fmt.Sprintf("/machines/%s/snapshots", machineID)
+	_, err := c.Request("GET", url, params, &snapshots, params.RequestParams.withOperation("GetMachineSnapshots"))
+
+	return snapshots, err
+}
+
+// RestoreMachineSnapshot reverts the machine's disk to the snapshot, the machine must be off
+func (c Client) RestoreMachineSnapshot(machineID string, snapshotID string, params SnapshotRestoreParams) error {
+	return c.RestoreMachineSnapshotContext(params.RequestParams.contextOrBackground(), machineID, snapshotID, params)
+}
+
+func (c Client) RestoreMachineSnapshotContext(ctx context.Context, machineID string, snapshotID string, params SnapshotRestoreParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/machines/%s/snapshots/%s/restore", machineID, snapshotID)
+	_, err := c.Request("POST", url, nil, nil, params.RequestParams.withOperation("RestoreMachineSnapshot"))
+
+	return err
+}
+
+// SetMachineRestorePoint marks the snapshot as the machine's restore point,
+// which is reported by Machine.RestorePointSnapshotID
+func (c Client) SetMachineRestorePoint(machineID string, snapshotID string, params SnapshotRestorePointParams) error {
+	return c.SetMachineRestorePointContext(params.RequestParams.contextOrBackground(), machineID, snapshotID, params)
+}
+
+func (c Client) SetMachineRestorePointContext(ctx context.Context, machineID string, snapshotID string, params SnapshotRestorePointParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/machines/%s/snapshots/%s/restorePoint", machineID, snapshotID)
+	_, err := c.Request("POST", url, nil, nil, params.RequestParams.withOperation("SetMachineRestorePoint"))
+
+	return err
+}
+
+func (c Client) DeleteMachineSnapshot(machineID string, snapshotID string, params SnapshotDeleteParams) error {
+	return c.DeleteMachineSnapshotContext(params.RequestParams.contextOrBackground(), machineID, snapshotID, params)
+}
+
+func (c Client) DeleteMachineSnapshotContext(ctx context.Context, machineID string, snapshotID string, params SnapshotDeleteParams) error {
+	params.Context = ctx
+
+	url := fmt.Sprintf("/machines/%s/snapshots/%s", machineID, snapshotID)
+	_, err := c.Request("DELETE", url, nil, nil, params.RequestParams.withOperation("DeleteMachineSnapshot"))
+
+	return err
+}
@@ -0,0 +1,96 @@
+package paperspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Template struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Label     string    `json:"label"`
+	OS        string    `json:"os"`
+	TeamID    string    `json:"teamId"`
+	UserID    string    `json:"userId"`
+	Region    string    `json:"region"`
+	IsDefault bool      `json:"isDefault"`
+	DtCreated time.Time `json:"dtCreated"`
+}
+
+type TemplateGetParams struct {
+	RequestParams
+}
+
+type TemplateListParams struct {
+	RequestParams
+
+	Filter    Filter `json:"filter,omitempty"`
+	Label     string `json:"label,omitempty"`
+	OS        string `json:"os,omitempty"`
+	TeamID    string `json:"teamId,omitempty"`
+	IsDefault *bool  `json:"isDefault,omitempty"`
+}
+
+func (c Client) GetTemplate(id string, params TemplateGetParams) (Template, error) {
+	return c.GetTemplateContext(params.RequestParams.contextOrBackground(), id, params)
+}
+
+func (c Client) GetTemplateContext(ctx context.Context, id string, params TemplateGetParams) (Template, error) {
+	params.Context = ctx
+
+	template := Template{}
+
+	url := fmt.Sprintf("/templates/getTemplate?templateId=%s", id)
+	_, err := c.Request("GET", url, nil, &template, params.RequestParams.withOperation("GetTemplate"))
+
+	return template, err
+}
+
+func (c Client) GetTemplates(params TemplateListParams) ([]Template, error) {
+	return c.GetTemplatesContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetTemplatesContext(ctx context.Context, params TemplateListParams) ([]Template, error) {
+	params.Context = ctx
+
+	var templates []Template
+
+	url := "/templates/getTemplates"
+	_, err := c.Request("GET", url, params, &templates, params.RequestParams.withOperation("GetTemplates"))
+
+	return templates, err
+}
+
+// ResolveTemplateID returns the ID of the template with the given label, such
+// as "Ubuntu 20.04 Server". Labels are matched ignoring case and must be unique.
+func (c Client) ResolveTemplateID(label string, params TemplateListParams) (string, error) {
+	return c.ResolveTemplateIDContext(params.RequestParams.contextOrBackground(), label, params)
+}
+
+func (c Client) ResolveTemplateIDContext(ctx context.Context, label string, params TemplateListParams) (string, error) {
+	params.Label = label
+	params.RequestParams = params.RequestParams.withOperation("ResolveTemplateID")
+
+	templates, err := c.GetTemplatesContext(ctx, params)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []Template
+	for _, template := range templates {
+		if strings.EqualFold(template.Label, label) {
+			matches = append(matches, template)
+		}
+	}
+
+	if len(matches) == 0 {
+		return "", NotFoundError{Resource: "template", Key: "label " + label}
+	}
+	if len(matches) > 1 {
+		return "", fmt.Errorf("found more than one template with label %s", label)
+	}
+
+	return matches[0].ID, nil
+}
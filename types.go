@@ -11,3 +11,17 @@ func String(v string) *string {
 func Int(v int) *int {
 	return &v
 }
+
+func Strings(v ...string) *[]string {
+	if v == nil {
+		v = []string{}
+	}
+	return &v
+}
+
+func PortRanges(v ...PortRange) *[]PortRange {
+	if v == nil {
+		v = []PortRange{}
+	}
+	return &v
+}
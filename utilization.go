@@ -0,0 +1,236 @@
+package paperspace
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const billingMonthLayout = "2006-01"
+
+// DefaultUsageReportConcurrency is the number of utilization requests
+// GetUsageReport makes at once unless UsageReportParams.Concurrency is set
+const DefaultUsageReportConcurrency = 4
+
+type MachineUtilization struct {
+	MachineID          string              `json:"machineId"`
+	BillingMonth       string              `json:"billingMonth"`
+	Utilization        MachineUsage        `json:"utilization"`
+	StorageUtilization MachineStorageUsage `json:"storageUtilization"`
+}
+
+type MachineUsage struct {
+	SecondsUsed float64 `json:"secondsUsed"`
+	HourlyRate  Money   `json:"hourlyRate"`
+}
+
+type MachineStorageUsage struct {
+	SecondsUsed float64 `json:"secondsUsed"`
+	MonthlyRate Money   `json:"monthlyRate"`
+}
+
+// Cost is the hourly rate charged for every second used
+func (u MachineUsage) Cost() Money {
+	return u.HourlyRate.MulRat(int64(math.Round(u.SecondsUsed)), int64(time.Hour/time.Second))
+}
+
+// Cost is the monthly rate prorated by the seconds used out of the seconds in billingMonth
+func (u MachineStorageUsage) Cost(billingMonth string) (Money, error) {
+	start, err := time.Parse(billingMonthLayout, billingMonth)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid billing month %s, expected YYYY-MM", billingMonth)
+	}
+	secondsInMonth := int64(start.AddDate(0, 1, 0).Sub(start) / time.Second)
+
+	return u.MonthlyRate.MulRat(int64(math.Round(u.SecondsUsed)), secondsInMonth), nil
+}
+
+func (u MachineUtilization) MachineCost() Money {
+	return u.Utilization.Cost()
+}
+
+func (u MachineUtilization) StorageCost() Money {
+	cost, _ := u.StorageUtilization.Cost(u.BillingMonth)
+	return cost
+}
+
+func (u MachineUtilization) Cost() Money {
+	return u.MachineCost().Add(u.StorageCost())
+}
+
+type MachineUtilizationParams struct {
+	RequestParams
+}
+
+type UsageReportParams struct {
+	RequestParams
+
+	// BillingMonth is formatted as YYYY-MM
+	BillingMonth string `json:"-"`
+	// Filter selects machines from GetMachines, which only lists machines
+	// that still exist
+	Filter Filter `json:"-"`
+	// Concurrency is the number of utilization requests made at once
+	Concurrency int `json:"-"`
+}
+
+// UsageTotal adds up the usage of one or more machines
+type UsageTotal struct {
+	Machines    int
+	SecondsUsed float64
+	MachineCost Money
+	StorageCost Money
+	Cost        Money
+}
+
+func (t *UsageTotal) add(utilization MachineUtilization) {
+	t.Machines++
+	t.SecondsUsed += utilization.Utilization.SecondsUsed
+	t.MachineCost = t.MachineCost.Add(utilization.MachineCost())
+	t.StorageCost = t.StorageCost.Add(utilization.StorageCost())
+	t.Cost = t.Cost.Add(utilization.Cost())
+}
+
+type MachineUsageReport struct {
+	Machine     Machine
+	Utilization MachineUtilization
+}
+
+type UsageReport struct {
+	BillingMonth  string
+	Machines      []MachineUsageReport
+	ByMachineType map[string]UsageTotal
+	ByRegion      map[string]UsageTotal
+	Total         UsageTotal
+}
+
+func (c Client) GetMachineUtilization(id string, billingMonth string, params MachineUtilizationParams) (MachineUtilization, error) {
+	return c.GetMachineUtilizationContext(params.RequestParams.contextOrBackground(), id, billingMonth, params)
+}
+
+func (c Client) GetMachineUtilizationContext(ctx context.Context, id string, billingMonth string, params MachineUtilizationParams) (MachineUtilization, error) {
+	params.Context = ctx
+
+	utilization := MachineUtilization{}
+	if _, err := time.Parse(billingMonthLayout, billingMonth); err != nil {
+		return utilization, fmt.Errorf("invalid billing month %s, expected YYYY-MM", billingMonth)
+	}
+
+	url := fmt.Sprintf("/machines/getUtilization?machineId=%s&billingMonth=%s", id, billingMonth)
+	_, err := c.Request("GET", url, nil, &utilization, params.RequestParams.withOperation("GetMachineUtilization"))
+	if utilization.BillingMonth == "" {
+		utilization.BillingMonth = billingMonth
+	}
+
+	return utilization, err
+}
+
+// GetUsageReport fetches the utilization of every machine matching the filter
+// and adds it up per machine type and per region. Machines are listed with
+// GetMachines, so machines deleted during the billing month and their cost are
+// not part of the report; use GetMachineUtilization for those.
+func (c Client) GetUsageReport(params UsageReportParams) (UsageReport, error) {
+	return c.GetUsageReportContext(params.RequestParams.contextOrBackground(), params)
+}
+
+func (c Client) GetUsageReportContext(ctx context.Context, params UsageReportParams) (UsageReport, error) {
+	params.Context = ctx
+
+	report := UsageReport{
+		BillingMonth:  params.BillingMonth,
+		ByMachineType: make(map[string]UsageTotal),
+		ByRegion:      make(map[string]UsageTotal),
+	}
+	if _, err := time.Parse(billingMonthLayout, params.BillingMonth); err != nil {
+		return report, fmt.Errorf("invalid billing month %s, expected YYYY-MM", params.BillingMonth)
+	}
+
+	requestParams := params.RequestParams.withOperation("GetUsageReport")
+	machines, err := c.GetMachinesContext(ctx, MachineListParams{RequestParams: requestParams, Filter: params.Filter})
+	if err != nil {
+		return report, err
+	}
+
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultUsageReportConcurrency
+	}
+	utilizations, err := c.getMachineUtilizations(ctx, machines, params.BillingMonth, requestParams, concurrency)
+	if err != nil {
+		return report, err
+	}
+
+	for i, machine := range machines {
+		utilization := utilizations[i]
+		report.Machines = append(report.Machines, MachineUsageReport{Machine: machine, Utilization: utilization})
+
+		machineTypeTotal := report.ByMachineType[machine.MachineType]
+		machineTypeTotal.add(utilization)
+		report.ByMachineType[machine.MachineType] = machineTypeTotal
+
+		regionTotal := report.ByRegion[machine.Region]
+		regionTotal.add(utilization)
+		report.ByRegion[machine.Region] = regionTotal
+
+		report.Total.add(utilization)
+	}
+
+	return report, nil
+}
+
+// getMachineUtilizations fetches the utilization of each machine with at most
+// concurrency requests in flight, stopping at the first error
+func (c Client) getMachineUtilizations(ctx context.Context, machines []Machine, billingMonth string, requestParams RequestParams, concurrency int) ([]MachineUtilization, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	utilizations := make([]MachineUtilization, len(machines))
+	indexes := make(chan int)
+	go func() {
+		defer close(indexes)
+		for i := range machines {
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for worker := 0; worker < concurrency && worker < len(machines); worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				id := machines[i].ID
+				utilization, err := c.GetMachineUtilizationContext(ctx, id, billingMonth, MachineUtilizationParams{RequestParams: requestParams})
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("getting utilization of machine %s: %w", id, err)
+						cancel()
+					})
+					continue
+				}
+				utilizations[i] = utilization
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// The machines left unsent when the caller's context ends have no error
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return utilizations, nil
+}
@@ -0,0 +1,103 @@
+package paperspace
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func newUsageReportTestClient(t *testing.T, utilizations map[string]string) (*Client, *int32) {
+	t.Helper()
+
+	var requests int32
+	backend := BackendFunc(func(method string, url string, params, result interface{}, requestParams RequestParams) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+
+		body := `[{"id":"ps1","machineType":"C5","region":"East Coast (NY2)"},` +
+			`{"id":"ps2","machineType":"C5","region":"Europe (AMS1)"},` +
+			`{"id":"ps3","machineType":"P4000","region":"East Coast (NY2)"}]`
+		if strings.HasPrefix(url, "/machines/getUtilization") {
+			id := url[strings.Index(url, "machineId=")+len("machineId=") : strings.Index(url, "&")]
+			var ok bool
+			if body, ok = utilizations[id]; !ok {
+				return nil, &APIError{StatusCode: http.StatusNotFound, Method: method, URL: url}
+			}
+		}
+
+		return &http.Response{StatusCode: http.StatusOK}, json.Unmarshal([]byte(body), result)
+	})
+
+	return NewClient(WithAPIKey("test"), WithBackend(backend)), &requests
+}
+
+func TestGetUsageReport(t *testing.T) {
+	client, requests := newUsageReportTestClient(t, map[string]string{
+		"ps1": `{"machineId":"ps1","utilization":{"secondsUsed":3600,"hourlyRate":"0.10"},` +
+			`"storageUtilization":{"secondsUsed":2505600,"monthlyRate":"5.00"}}`,
+		"ps2": `{"machineId":"ps2","utilization":{"secondsUsed":7200,"hourlyRate":"0.10"}}`,
+		"ps3": `{"machineId":"ps3","utilization":{"secondsUsed":1800,"hourlyRate":0.51}}`,
+	})
+
+	report, err := client.GetUsageReport(UsageReportParams{BillingMonth: "2024-02", Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 4 {
+		t.Errorf("got %d requests, want 4", *requests)
+	}
+
+	for i, id := range []string{"ps1", "ps2", "ps3"} {
+		if report.Machines[i].Machine.ID != id || report.Machines[i].Utilization.MachineID != id {
+			t.Errorf("got machine %d %s with utilization of %s, want %s", i, report.Machines[i].Machine.ID, report.Machines[i].Utilization.MachineID, id)
+		}
+	}
+
+	totals := []struct {
+		name     string
+		total    UsageTotal
+		machines int
+		cost     string
+	}{
+		{"total", report.Total, 3, "5.555"},
+		{"C5", report.ByMachineType["C5"], 2, "5.30"},
+		{"P4000", report.ByMachineType["P4000"], 1, "0.255"},
+		{"NY2", report.ByRegion["East Coast (NY2)"], 2, "5.355"},
+		{"AMS1", report.ByRegion["Europe (AMS1)"], 1, "0.20"},
+	}
+	for _, total := range totals {
+		if total.total.Machines != total.machines || total.total.Cost.String() != total.cost {
+			t.Errorf("got %s total of %d machines costing %s, want %d costing %s",
+				total.name, total.total.Machines, total.total.Cost, total.machines, total.cost)
+		}
+	}
+	if report.Total.StorageCost.String() != "5.00" {
+		t.Errorf("got storage cost %s, want 5.00", report.Total.StorageCost)
+	}
+}
+
+func TestGetUsageReportInvalidBillingMonth(t *testing.T) {
+	client, requests := newUsageReportTestClient(t, nil)
+
+	for _, billingMonth := range []string{"", "2024-2", "02-2024"} {
+		if _, err := client.GetUsageReport(UsageReportParams{BillingMonth: billingMonth}); err == nil {
+			t.Errorf("got no error for billing month %q", billingMonth)
+		}
+	}
+	if *requests != 0 {
+		t.Errorf("got %d requests, want none", *requests)
+	}
+}
+
+func TestGetUsageReportUtilizationError(t *testing.T) {
+	client, _ := newUsageReportTestClient(t, map[string]string{
+		"ps1": `{"machineId":"ps1"}`,
+		"ps3": `{"machineId":"ps3"}`,
+	})
+
+	_, err := client.GetUsageReport(UsageReportParams{BillingMonth: "2024-02"})
+	if !IsNotFound(err) || !strings.Contains(err.Error(), "ps2") {
+		t.Errorf("got error %v, want ps2 not found", err)
+	}
+}
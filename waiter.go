@@ -0,0 +1,215 @@
+package paperspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var ErrUnknownClusterStatus = errors.New("unknown cluster status")
+
+var DefaultWaitPollInterval = 5 * time.Second
+var DefaultWaitMaxPollInterval = 30 * time.Second
+
+// WaitOptions controls how often waiters poll the API and for how long
+type WaitOptions struct {
+	// PollInterval is the wait between the first two polls
+	PollInterval time.Duration
+	// BackoffFactor multiplies the interval after each poll, 1 or less keeps it fixed
+	BackoffFactor float64
+	// MaxPollInterval caps the interval as it grows
+	MaxPollInterval time.Duration
+	// Timeout stops waiting after the given duration, zero waits until ctx is done
+	Timeout time.Duration
+}
+
+type MachineWaitOptions struct {
+	WaitOptions
+
+	// FailureStates stop the wait with an error when the machine reaches them
+	FailureStates []MachineState
+	// OnProgress is called with the machine returned by every poll
+	OnProgress func(Machine)
+}
+
+type ClusterWaitOptions struct {
+	WaitOptions
+
+	// OnProgress is called with the cluster returned by every poll
+	OnProgress func(Cluster)
+}
+
+// MachineStateError is returned when a waited on machine reaches a failure
+// state or is deleted
+type MachineStateError struct {
+	Machine Machine
+	Desired MachineState
+}
+
+func (e MachineStateError) Error() string {
+	if !e.Machine.DtDeleted.IsZero() {
+		return fmt.Sprintf("machine %s was deleted while waiting for state %s", e.Machine.ID, e.Desired)
+	}
+
+	return fmt.Sprintf("machine %s reached state %s while waiting for state %s", e.Machine.ID, e.Machine.State, e.Desired)
+}
+
+// ClusterStatusError is returned when the workflow provisioning a waited on
+// cluster fails or the cluster is deleted
+type ClusterStatusError struct {
+	Cluster Cluster
+}
+
+func (e ClusterStatusError) Error() string {
+	if e.Cluster.StatusMessage != "" {
+		return fmt.Sprintf("cluster %s is %s: %s", e.Cluster.ID, e.Cluster.Status, e.Cluster.StatusMessage)
+	}
+
+	return fmt.Sprintf("cluster %s is %s", e.Cluster.ID, e.Cluster.Status)
+}
+
+// WaitForMachineState polls the machine until it reaches the desired state
+func (c Client) WaitForMachineState(ctx context.Context, id string, desired MachineState, opts MachineWaitOptions) (Machine, error) {
+	machine := Machine{}
+
+	err := poll(ctx, opts.WaitOptions, func(ctx context.Context) (bool, error) {
+		var err error
+		machine, err = c.GetMachineContext(ctx, id, MachineGetParams{})
+		if err != nil {
+			return false, err
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(machine)
+		}
+
+		if machine.State == desired {
+			return true, nil
+		}
+		if !machine.DtDeleted.IsZero() {
+			return false, MachineStateError{Machine: machine, Desired: desired}
+		}
+		for _, state := range opts.FailureStates {
+			if machine.State == state {
+				return false, MachineStateError{Machine: machine, Desired: desired}
+			}
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return machine, fmt.Errorf("waiting for machine %s to be %s: %w", id, desired, err)
+	}
+
+	return machine, nil
+}
+
+// WaitForMachineDeleted polls the machine until it is marked deleted or no longer found
+func (c Client) WaitForMachineDeleted(ctx context.Context, id string, opts MachineWaitOptions) error {
+	err := poll(ctx, opts.WaitOptions, func(ctx context.Context) (bool, error) {
+		machine, err := c.GetMachineContext(ctx, id, MachineGetParams{})
+		if IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(machine)
+		}
+
+		return !machine.DtDeleted.IsZero(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for machine %s to be deleted: %w", id, err)
+	}
+
+	return nil
+}
+
+// WaitForClusterReady polls the cluster until its provisioning workflow
+// succeeds, returning a ClusterStatusError with the failure reason if it fails
+// and ErrUnknownClusterStatus when the status is missing or not one of the
+// ClusterStatus constants
+func (c Client) WaitForClusterReady(ctx context.Context, id string, opts ClusterWaitOptions) (Cluster, error) {
+	cluster := Cluster{}
+
+	err := poll(ctx, opts.WaitOptions, func(ctx context.Context) (bool, error) {
+		var err error
+		cluster, err = c.GetClusterContext(ctx, id, ClusterGetParams{})
+		if err != nil {
+			return false, err
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(cluster)
+		}
+
+		switch cluster.Status {
+		case ClusterStatusReady:
+			return true, nil
+		case ClusterStatusPending, ClusterStatusProvisioning:
+			return false, nil
+		case ClusterStatusFailed, ClusterStatusDeleting, ClusterStatusDeleted:
+			return false, ClusterStatusError{Cluster: cluster}
+		}
+
+		// a status this client doesn't know can't be waited on
+		return false, fmt.Errorf("%w %q", ErrUnknownClusterStatus, cluster.Status)
+	})
+	if err != nil {
+		return cluster, fmt.Errorf("waiting for cluster %s to be ready: %w", id, err)
+	}
+
+	return cluster, nil
+}
+
+// poll calls check until it is done or fails, waiting between calls as set by
+// opts. Rate limited, server and network errors are retried on the next poll.
+func poll(ctx context.Context, opts WaitOptions, check func(ctx context.Context) (bool, error)) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultWaitPollInterval
+	}
+	maxInterval := opts.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultWaitMaxPollInterval
+	}
+
+	var lastErr error
+	for {
+		done, err := check(ctx)
+		switch {
+		case err == nil && done:
+			return nil
+		case err == nil:
+			lastErr = nil
+		case ctx.Err() == nil && isTransientError(err):
+			lastErr = err
+		default:
+			return err
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return newContextError(err, lastErr)
+		}
+
+		if opts.BackoffFactor > 1 {
+			interval = time.Duration(math.Min(float64(interval)*opts.BackoffFactor, float64(maxInterval)))
+		}
+	}
+}
+
+// isTransientError reports whether a poll that failed with err may succeed later
+func isTransientError(err error) bool {
+	return IsRateLimited(err) || IsServerError(err) || (StatusCode(err) == 0 && isNetworkError(err))
+}
@@ -0,0 +1,156 @@
+package paperspace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testResponse struct {
+	statusCode int
+	body       string
+}
+
+// newTestClient returns a client, without retries, whose requests are answered
+// with responses in order, repeating the last one
+func newTestClient(t *testing.T, responses ...testResponse) (*Client, *int32) {
+	t.Helper()
+
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&polls, 1)) - 1
+		if i >= len(responses) {
+			i = len(responses) - 1
+		}
+		w.WriteHeader(responses[i].statusCode)
+		w.Write([]byte(responses[i].body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(
+		WithAPIKey("test"),
+		WithBaseURL(server.URL),
+		WithRetryPolicy(&RetryPolicy{}),
+	)
+
+	return client, &polls
+}
+
+var testWaitOptions = WaitOptions{PollInterval: time.Millisecond, Timeout: 10 * time.Second}
+
+func TestWaitForMachineStateTransientErrors(t *testing.T) {
+	client, polls := newTestClient(t,
+		testResponse{http.StatusServiceUnavailable, `{"error":{"message":"unavailable"}}`},
+		testResponse{http.StatusTooManyRequests, `{"error":{"message":"slow down"}}`},
+		testResponse{http.StatusOK, `{"id":"ps123","state":"provisioning"}`},
+		testResponse{http.StatusBadGateway, ``},
+		testResponse{http.StatusOK, `{"id":"ps123","state":"ready"}`},
+	)
+
+	machine, err := client.WaitForMachineState(context.Background(), "ps123", MachineStateReady, MachineWaitOptions{WaitOptions: testWaitOptions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if machine.State != MachineStateReady {
+		t.Errorf("got state %s, want %s", machine.State, MachineStateReady)
+	}
+	if got := atomic.LoadInt32(polls); got != 5 {
+		t.Errorf("got %d polls, want 5", got)
+	}
+}
+
+func TestWaitForMachineStateClientError(t *testing.T) {
+	client, polls := newTestClient(t,
+		testResponse{http.StatusForbidden, `{"error":{"message":"forbidden"}}`},
+		testResponse{http.StatusOK, `{"id":"ps123","state":"ready"}`},
+	)
+
+	_, err := client.WaitForMachineState(context.Background(), "ps123", MachineStateReady, MachineWaitOptions{WaitOptions: testWaitOptions})
+	if !IsForbidden(err) {
+		t.Errorf("got error %v, want forbidden", err)
+	}
+	if got := atomic.LoadInt32(polls); got != 1 {
+		t.Errorf("got %d polls, want 1", got)
+	}
+}
+
+func TestWaitForMachineStateTimeout(t *testing.T) {
+	client, _ := newTestClient(t,
+		testResponse{http.StatusServiceUnavailable, `{"error":{"message":"unavailable"}}`},
+	)
+
+	opts := MachineWaitOptions{WaitOptions: WaitOptions{PollInterval: time.Millisecond, Timeout: 50 * time.Millisecond}}
+	_, err := client.WaitForMachineState(context.Background(), "ps123", MachineStateReady, opts)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want it to match context.DeadlineExceeded", err)
+	}
+}
+
+func TestWaitForMachineDeleted(t *testing.T) {
+	client, polls := newTestClient(t,
+		testResponse{http.StatusOK, `{"id":"ps123","state":"off"}`},
+		testResponse{http.StatusInternalServerError, `{"error":{"message":"internal"}}`},
+		testResponse{http.StatusNotFound, `{"error":{"message":"not found"}}`},
+	)
+
+	if err := client.WaitForMachineDeleted(context.Background(), "ps123", MachineWaitOptions{WaitOptions: testWaitOptions}); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(polls); got != 3 {
+		t.Errorf("got %d polls, want 3", got)
+	}
+}
+
+func TestWaitForClusterReady(t *testing.T) {
+	client, polls := newTestClient(t,
+		testResponse{http.StatusOK, `{"id":"cl123","status":"pending"}`},
+		testResponse{http.StatusServiceUnavailable, ``},
+		testResponse{http.StatusOK, `{"id":"cl123","status":"provisioning"}`},
+		testResponse{http.StatusOK, `{"id":"cl123","status":"ready"}`},
+	)
+
+	cluster, err := client.WaitForClusterReady(context.Background(), "cl123", ClusterWaitOptions{WaitOptions: testWaitOptions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.Status != ClusterStatusReady {
+		t.Errorf("got status %s, want %s", cluster.Status, ClusterStatusReady)
+	}
+	if got := atomic.LoadInt32(polls); got != 4 {
+		t.Errorf("got %d polls, want 4", got)
+	}
+}
+
+func TestWaitForClusterReadyFailed(t *testing.T) {
+	client, _ := newTestClient(t,
+		testResponse{http.StatusOK, `{"id":"cl123","status":"failed","statusMessage":"quota exceeded"}`},
+	)
+
+	_, err := client.WaitForClusterReady(context.Background(), "cl123", ClusterWaitOptions{WaitOptions: testWaitOptions})
+
+	var statusError ClusterStatusError
+	if !errors.As(err, &statusError) {
+		t.Fatalf("got error %v, want a ClusterStatusError", err)
+	}
+	if statusError.Cluster.StatusMessage != "quota exceeded" {
+		t.Errorf("got status message %q, want %q", statusError.Cluster.StatusMessage, "quota exceeded")
+	}
+}
+
+func TestWaitForClusterReadyUnknownStatus(t *testing.T) {
+	for _, body := range []string{`{"id":"cl123"}`, `{"id":"cl123","status":"hibernating"}`} {
+		client, polls := newTestClient(t, testResponse{http.StatusOK, body})
+
+		_, err := client.WaitForClusterReady(context.Background(), "cl123", ClusterWaitOptions{})
+		if !errors.Is(err, ErrUnknownClusterStatus) {
+			t.Errorf("got error %v for %s, want ErrUnknownClusterStatus", err, body)
+		}
+		if got := atomic.LoadInt32(polls); got != 1 {
+			t.Errorf("got %d polls for %s, want 1", got, body)
+		}
+	}
+}